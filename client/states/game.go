@@ -3,6 +3,7 @@ package states
 import (
 	"fmt"
 	"image/color"
+	"math"
 
 	"github.com/ebitenui/ebitenui"
 	"github.com/ebitenui/ebitenui/widget"
@@ -36,18 +37,23 @@ type Game struct {
 	showGrid              bool
 	pendingDesire         game.Desire
 	pathingSteps          []pathing.Step
+	inTurns               bool // Whether the current location is processing in turns.
+	turn                  int
 	//
-	binds    clgame.Binds
-	scroller clgame.Scroller
-	grid     clgame.Grid
-	actioner clgame.Actioner
-	pather   clgame.Pather
-	pinger   clgame.Pinger
-	sounds   clgame.Sounds
-	kickers  clgame.Kickers
+	binds       clgame.Binds
+	scroller    clgame.Scroller
+	grid        clgame.Grid
+	actioner    clgame.Actioner
+	pather      clgame.Pather
+	pinger      clgame.Pinger
+	sounds      clgame.Sounds
+	kickers     clgame.Kickers
+	targeter    clgame.Targeter
+	projectiles clgame.Projectiles
 	//
 	inventory clgame.Inventory
 	below     clgame.Below
+	bag       clgame.BagView
 	hotbar    clgame.Hotbar
 	statbar   clgame.Statbar
 	lc        locale.Localizer
@@ -82,6 +88,8 @@ func NewGame(connection net.Connection, msgCh chan net.Message, data *Data) *Gam
 		state.kickers.SetOffset(x, y)
 		state.pather.SetOffset(x, y)
 		state.pinger.SetOffset(x, y)
+		state.targeter.SetOffset(x, y)
+		state.projectiles.SetOffset(x, y)
 	})
 	state.grid.SetColor(color.NRGBA{255, 255, 255, 30})
 	state.grid.SetHeldHandler(func(x, y int) {
@@ -90,6 +98,11 @@ func NewGame(connection net.Connection, msgCh chan net.Message, data *Data) *Gam
 			if cell.Blocks == game.MovementWalk || cell.Blocks == game.MovementAll {
 				return pathing.MaximumCost
 			}
+			if cell.TileID != nil {
+				if t, ok := state.data.Archetype(*cell.TileID).(game.TileArchetype); ok {
+					return uint32(t.MoveCost)
+				}
+			}
 			return 0
 		}, pathing.AlgorithmAStar)
 		path.AllowDiagonals(true)
@@ -106,6 +119,21 @@ func NewGame(connection net.Connection, msgCh chan net.Message, data *Data) *Gam
 		})
 	}
 
+	state.targeter.Target = func(kind clgame.TargetKind, wid id.WID, x, y int) {
+		switch kind {
+		case clgame.TargetKindShoot:
+			state.sendDesire(state.characterWID, game.DesireShoot{
+				WID:      wid,
+				Position: game.Position{X: x, Y: y},
+			})
+		case clgame.TargetKindThrow:
+			state.sendDesire(state.characterWID, game.DesireThrow{
+				WID:      wid,
+				Position: game.Position{X: x, Y: y},
+			})
+		}
+	}
+
 	state.inventory.Data = data
 	state.inventory.DropItem = func(wid id.WID) {
 		state.sendDesire(state.characterWID, game.DesireDrop{
@@ -113,6 +141,11 @@ func NewGame(connection net.Connection, msgCh chan net.Message, data *Data) *Gam
 		})
 	}
 	state.inventory.ApplyItem = func(wid id.WID, apply bool) {
+		// Applying a bag opens or closes it.
+		if _, ok := state.location.ObjectByWID(wid).(*game.Bag); ok {
+			state.toggleBag(wid)
+			return
+		}
 		state.sendDesire(state.characterWID, game.DesireApply{
 			WID:   wid,
 			Apply: apply,
@@ -123,6 +156,11 @@ func NewGame(connection net.Connection, msgCh chan net.Message, data *Data) *Gam
 			WID: wid,
 		})
 	}
+	state.inventory.UnstashItem = func(wid id.WID) {
+		state.sendDesire(state.characterWID, game.DesireUnstash{
+			WID: wid,
+		})
+	}
 
 	state.below.Data = data
 	state.below.PickupItem = func(wid id.WID) {
@@ -131,6 +169,17 @@ func NewGame(connection net.Connection, msgCh chan net.Message, data *Data) *Gam
 		})
 	}
 	state.below.ApplyItem = func(wid id.WID) {
+		// Applying stairs is our way of using them.
+		if _, ok := state.location.ObjectByWID(wid).(*game.Stairs); ok {
+			state.sendDesire(state.characterWID, game.DesireTravel{
+				WID: wid,
+			})
+			return
+		}
+		if _, ok := state.location.ObjectByWID(wid).(*game.Bag); ok {
+			state.toggleBag(wid)
+			return
+		}
 		state.sendDesire(state.characterWID, game.DesireApply{
 			WID: wid,
 		})
@@ -141,6 +190,19 @@ func NewGame(connection net.Connection, msgCh chan net.Message, data *Data) *Gam
 		})
 	}
 
+	state.bag.Data = data
+	state.bag.StashItem = func(wid id.WID, bag id.WID) {
+		state.sendDesire(state.characterWID, game.DesireStash{
+			WID: wid,
+			Bag: bag,
+		})
+	}
+	state.bag.UnstashItem = func(wid id.WID) {
+		state.sendDesire(state.characterWID, game.DesireUnstash{
+			WID: wid,
+		})
+	}
+
 	return state
 }
 
@@ -191,8 +253,16 @@ func (state *Game) Begin(ctx ifs.RunContext) error {
 		belowContainer.AddChild(belowContainerInner)
 		state.below.Init(belowContainerInner, ctx, &state.binds)
 
+		bagContainer := widget.NewContainer(
+			widget.ContainerOpts.Layout(widget.NewAnchorLayout(
+				widget.AnchorLayoutOpts.Padding(widget.NewInsetsSimple(8)),
+			)),
+		)
+		state.bag.Init(bagContainer, ctx)
+
 		invBelowContainerInner.AddChild(inventoryContainer)
 		invBelowContainerInner.AddChild(belowContainer)
+		invBelowContainerInner.AddChild(bagContainer)
 
 		invBelowContainer.AddChild(invBelowContainerInner)
 
@@ -289,24 +359,58 @@ func (state *Game) setLocationFromMessage(m net.LocationMessage) {
 		ID:      m.ID,
 		Cells:   m.Cells,
 		Objects: m.Objects,
+		Ambient: m.Ambient,
 	}
 
 	// Request objects we don't have.
 	state.ensureObjects(m.Objects)
 
+	for _, o := range l.Objects {
+		linkBagContents(l, o)
+	}
+
 	// Request tile objects.
+	var cells []game.Cell
+	for _, r := range l.Cells {
+		cells = append(cells, r...)
+	}
+	state.ensureTiles(cells)
+
+	state.locations[m.ID] = l
+}
+
+// setCellsFromMessage updates the cells that have come into view.
+func (state *Game) setCellsFromMessage(m net.CellsMessage) {
+	l, ok := state.locations[m.ID]
+	if !ok {
+		return
+	}
+	var cells []game.Cell
+	for _, c := range m.Cells {
+		if c.X < 0 || c.Y < 0 || c.X >= len(l.Cells) || c.Y >= len(l.Cells[c.X]) {
+			continue
+		}
+		l.Cells[c.X][c.Y] = c.Cell
+		cells = append(cells, c.Cell)
+	}
+	state.ensureTiles(cells)
+}
+
+// ensureTiles requests the tile archetypes of the given cells that we don't have.
+func (state *Game) ensureTiles(cells []game.Cell) {
 	var missingTiles []id.UUID
 	missingTiles2 := make(map[id.UUID]bool)
-	for _, r := range l.Cells {
-		for _, c := range r {
-			if c.TileID == nil {
-				continue
-			}
+	for _, c := range cells {
+		if c.TileID == nil {
+			continue
+		}
+		if _, ok := state.data.archetypes[*c.TileID]; ok {
+			continue
+		}
 
-			if _, ok := missingTiles2[*c.TileID]; !ok {
-				missingTiles2[*c.TileID] = true
-				missingTiles = append(missingTiles, *c.TileID)
-			}
+		if _, ok := missingTiles2[*c.TileID]; !ok {
+			missingTiles2[*c.TileID] = true
+			missingTiles = append(missingTiles, *c.TileID)
 		}
 	}
 
@@ -315,8 +419,6 @@ func (state *Game) setLocationFromMessage(m net.LocationMessage) {
 			IDs: missingTiles,
 		})
 	}
-
-	state.locations[m.ID] = l
 }
 
 func (state *Game) travelTo(id id.UUID) {
@@ -325,6 +427,10 @@ func (state *Game) travelTo(id id.UUID) {
 		// TODO: Maybe request the location?
 		return
 	}
+	if state.location != l {
+		// Any path we were walking belongs to the old location.
+		state.pather.Steps = nil
+	}
 	state.location = l
 }
 
@@ -371,7 +477,9 @@ func (state *Game) ensureObjects(objects game.Objects) {
 
 func (state *Game) Update(ctx ifs.RunContext) error {
 	state.ui.Update()
-	state.grid.Update(ctx)
+	if !state.targeter.Active() {
+		state.grid.Update(ctx)
+	}
 	state.pinger.Update(ctx)
 	select {
 	case msg := <-state.messageChan:
@@ -383,6 +491,8 @@ func (state *Game) Update(ctx ifs.RunContext) error {
 			if ch := state.Character(); ch != nil {
 				state.centerCameraOn(ctx, ch)
 			}
+		case net.CellsMessage:
+			state.setCellsFromMessage(m)
 		case net.ArchetypeMessage:
 			fmt.Println(msg)
 		case net.ArchetypesMessage:
@@ -428,6 +538,11 @@ func (state *Game) Update(ctx ifs.RunContext) error {
 				}
 				state.refreshInventory(ctx)
 			}
+		case net.SkillsMessage:
+			if character := state.Character(); character != nil && character.WID == m.WID {
+				character.Skills = m.Skills
+				state.refreshStatbar(ctx)
+			}
 		case net.OwnerMessage:
 			state.characterWID = m.WID
 			if character := state.Character(); character != nil {
@@ -443,11 +558,11 @@ func (state *Game) Update(ctx ifs.RunContext) error {
 					// Also assign the object's container to be the player.
 					o.SetContainerWID(state.characterWID)
 				}
+				character.Skills = m.Skills
+
 				state.refreshInventory(ctx)
 				state.refreshStatbar(ctx)
 
-				character.Skills = m.Skills
-
 				// Center the camera on the character.
 				state.centerCameraOn(ctx, character)
 			}
@@ -465,6 +580,7 @@ func (state *Game) Update(ctx ifs.RunContext) error {
 
 	state.sounds.Update()
 	state.kickers.Update()
+	state.projectiles.Update()
 
 	if state.location != nil {
 		if character := state.Character(); character != nil {
@@ -477,7 +593,14 @@ func (state *Game) Update(ctx ifs.RunContext) error {
 			if state.binds.IsActionHeld("toggle-grid") == 0 {
 				state.showGrid = !state.showGrid
 			}
-			if desire := state.actioner.Update(state.binds); desire != nil {
+			if state.binds.IsActionHeld("shoot") == 0 {
+				state.beginTargeting(clgame.TargetKindShoot)
+			} else if state.binds.IsActionHeld("throw") == 0 {
+				state.beginTargeting(clgame.TargetKindThrow)
+			}
+			if state.targeter.Active() {
+				state.targeter.Update(ctx)
+			} else if desire := state.actioner.Update(state.binds); desire != nil {
 				state.sendDesire(state.characterWID, desire)
 				state.pather.Steps = nil
 			} else if desire := state.pather.Update(character); desire != nil {
@@ -494,27 +617,65 @@ func (state *Game) Update(ctx ifs.RunContext) error {
 					belowObjects = append(belowObjects, o)
 				}
 			}
-			state.below.Refresh(ctx, belowObjects)
+			state.below.Refresh(ctx, character, belowObjects)
+
+			// Close the bag if it has gone out of reach.
+			if state.bag.WID != 0 {
+				if b, ok := state.location.ObjectByWID(state.bag.WID).(*game.Bag); ok && b.Reachable(character) {
+					state.bag.Refresh(ctx, character, b)
+				} else {
+					state.bag.Close()
+				}
+			}
 		}
 	}
 
 	return nil
 }
 
+// beginTargeting starts targeting with the character's ranged weapon or a throwable weapon.
+func (state *Game) beginTargeting(kind clgame.TargetKind) {
+	character := state.Character()
+	if character == nil {
+		return
+	}
+	for _, o := range character.Inventory {
+		w, ok := o.(*game.Weapon)
+		if !ok || w.Archetype == nil {
+			continue
+		}
+		a := w.Archetype.(game.WeaponArchetype)
+		if kind == clgame.TargetKindShoot && w.Applied && a.WeaponType == game.WeaponTypeRange {
+			state.targeter.Begin(kind, w.WID)
+			return
+		} else if kind == clgame.TargetKindThrow && a.WeaponType == game.WeaponTypeThrown {
+			state.targeter.Begin(kind, w.WID)
+			return
+		}
+	}
+	if kind == clgame.TargetKindShoot {
+		fmt.Println(state.lc.T("You have nothing to shoot with."))
+	} else {
+		fmt.Println(state.lc.T("You have nothing to throw."))
+	}
+}
+
 func (state *Game) handleEvent(e game.Event, ctx ifs.RunContext) {
 	if state.location == nil {
 		return
 	}
 	switch evt := e.(type) {
 	case game.EventAdd:
+		// Objects may be coming into view for the first time, so make sure we have their archetypes.
 		if ch := state.location.ObjectByWID(evt.Object.GetWID()); ch == nil {
 			state.location.Objects.Add(evt.Object)
-			state.assignObjectArchetype(evt.Object)
+			state.ensureObjects(game.Objects{evt.Object})
 		} else {
 			state.location.Objects.RemoveByWID(evt.Object.GetWID())
 			state.location.Objects.Add(evt.Object)
-			state.assignObjectArchetype(evt.Object)
+			state.ensureObjects(game.Objects{evt.Object})
 		}
+		linkBagContents(state.location, evt.Object)
 	case game.EventRemove:
 		if o := state.location.ObjectByWID(evt.WID); o != nil {
 			if co := o.GetContainerWID(); co > 0 {
@@ -527,9 +688,17 @@ func (state *Game) handleEvent(e game.Event, ctx ifs.RunContext) {
 						if c == state.Character() {
 							state.refreshInventory(ctx)
 						}
+					case *game.Bag:
+						c.Drop(o)
 					}
 				}
 			}
+			// A bag's contents go with it.
+			if b, ok := o.(*game.Bag); ok {
+				for _, o2 := range b.Inventory {
+					state.location.Objects.RemoveByWID(o2.GetWID())
+				}
+			}
 		}
 		state.location.Objects.RemoveByWID(evt.WID)
 	case game.EventPosition:
@@ -591,6 +760,19 @@ func (state *Game) handleEvent(e game.Event, ctx ifs.RunContext) {
 				}
 			}
 		}
+	case game.EventUse:
+		if item, ok := state.location.ObjectByWID(evt.WID).(*game.Item); ok {
+			item.Charges = evt.Charges
+			item.Identified = true
+			if evt.User == state.characterWID {
+				state.refreshInventory(ctx)
+			}
+		}
+	case game.EventIdentify:
+		if item, ok := state.location.ObjectByWID(evt.WID).(*game.Item); ok {
+			item.Identified = true
+			state.refreshInventory(ctx)
+		}
 	case game.EventHunger:
 		if o := state.location.ObjectByWID(evt.WID); o != nil {
 			if ch := state.location.Character(evt.WID); ch != nil {
@@ -600,6 +782,38 @@ func (state *Game) handleEvent(e game.Event, ctx ifs.RunContext) {
 				}
 			}
 		}
+	case game.EventHungerState:
+		if ch := state.location.Character(evt.WID); ch != nil {
+			ch.HungerState = evt.State
+			ch.Movable.CalculateFromCharacter(ch)
+			if ch == state.Character() {
+				state.refreshStatbar(ctx)
+				if evt.State != game.HungerStateFed {
+					state.kickers.Add(clgame.Kicker{
+						Message:  evt.State.String(),
+						Position: ch.Position,
+						Lifetime: 90,
+						Color:    evt.State.Color().(color.NRGBA),
+					})
+				}
+			}
+		}
+	case game.EventEncumbrance:
+		if ch := state.location.Character(evt.WID); ch != nil {
+			ch.Encumbrance = evt.Encumbrance
+			ch.Movable.CalculateFromCharacter(ch)
+			if ch == state.Character() {
+				state.refreshStatbar(ctx)
+				if evt.Encumbrance != game.EncumbranceNone {
+					state.kickers.Add(clgame.Kicker{
+						Message:  evt.Encumbrance.String(),
+						Position: ch.Position,
+						Lifetime: 90,
+						Color:    evt.Encumbrance.Color().(color.NRGBA),
+					})
+				}
+			}
+		}
 	case game.EventPickup:
 		if o := state.location.ObjectByWID(evt.WID); o != nil {
 			if picker := state.location.ObjectByWID(evt.Picker); picker != nil {
@@ -608,6 +822,7 @@ func (state *Game) handleEvent(e game.Event, ctx ifs.RunContext) {
 					if ch == state.Character() {
 						fmt.Println("You picked up an item")
 						state.refreshInventory(ctx)
+						state.refreshStatbar(ctx)
 					} else {
 						fmt.Printf("%s picked up an item\n", ch.Name)
 					}
@@ -623,6 +838,8 @@ func (state *Game) handleEvent(e game.Event, ctx ifs.RunContext) {
 		}
 
 		o := state.location.ObjectByWID(evt.Object.GetWID())
+		state.ensureObjects(game.Objects{o})
+		linkBagContents(state.location, o)
 		o.SetPosition(evt.Position) // Set the object's position to the dropped position.
 		if dropper := state.location.ObjectByWID(evt.Dropper); dropper != nil {
 			if ch, ok := dropper.(*game.Character); ok {
@@ -634,14 +851,175 @@ func (state *Game) handleEvent(e game.Event, ctx ifs.RunContext) {
 				} else {
 					fmt.Printf("%s dropped an item\n", ch.Name)
 				}
+			} else if b, ok := dropper.(*game.Bag); ok {
+				// Broken bags spill their contents.
+				b.Drop(o)
+			}
+		}
+	case game.EventStash:
+		if o := state.location.ObjectByWID(evt.WID); o != nil {
+			if b, ok := state.location.ObjectByWID(evt.Bag).(*game.Bag); ok {
+				if ch := state.location.Character(evt.Stasher); ch != nil {
+					ch.Stash(o, b)
+					if ch == state.Character() {
+						state.refreshInventory(ctx)
+						state.refreshStatbar(ctx)
+					}
+				}
+			}
+		}
+	case game.EventUnstash:
+		if o := state.location.ObjectByWID(evt.WID); o != nil {
+			if b, ok := state.location.ObjectByWID(evt.Bag).(*game.Bag); ok {
+				if ch := state.location.Character(evt.Unstasher); ch != nil {
+					ch.Unstash(o, b)
+					if ch == state.Character() {
+						state.refreshInventory(ctx)
+						state.refreshStatbar(ctx)
+					}
+				}
 			}
 		}
+	case game.EventBlocks:
+		if evt.X >= 0 && evt.Y >= 0 && evt.X < len(state.location.Cells) && evt.Y < len(state.location.Cells[evt.X]) {
+			state.location.Cells[evt.X][evt.Y].Blocks = evt.Blocks
+			state.location.Cells[evt.X][evt.Y].Opaque = evt.Opaque
+		}
+	case game.EventLock:
+		switch o := state.location.ObjectByWID(evt.WID).(type) {
+		case *game.Door:
+			o.Locked = evt.Locked
+		case *game.Bag:
+			o.Locked = evt.Locked
+		}
+	case game.EventTrap:
+		if o, ok := state.location.ObjectByWID(evt.WID).(*game.Trap); ok {
+			o.Revealed = evt.Revealed
+			o.Disarmed = evt.Disarmed
+		}
 	case game.EventTurn:
-		fmt.Println("turn", evt.Turn)
+		state.turn = evt.Turn
+		state.statbar.RefreshMode(ctx, state.lc, state.inTurns, state.turn)
+	case game.EventProjectile:
+		projectile := clgame.Projectile{
+			From: evt.FromPosition,
+			To:   evt.Position,
+		}
+		if evt.Weapon == game.WeaponTypeThrown {
+			if o := state.location.ObjectByWID(evt.WID); o != nil {
+				projectile.Image = state.data.archetypeImages[o.GetArchetypeID()]
+			}
+		}
+		state.projectiles.Add(projectile)
+	case game.EventMode:
+		state.inTurns = evt.InTurns
+		state.turn = evt.Turn
+		state.statbar.RefreshMode(ctx, state.lc, state.inTurns, state.turn)
 	case game.EventPing:
 		state.pinger.Add(evt.Position, evt.Kind)
 	case game.EventDamages:
-		fmt.Println("TODO: Handle damages", evt)
+		if o := state.location.ObjectByWID(evt.Target); o != nil {
+			for i, d := range evt.Damages {
+				msg := fmt.Sprintf("-%d", d.Damage)
+				clr := color.NRGBA{255, 128, 0, 255}
+				if d.Dodged {
+					msg = state.lc.T("dodge")
+					clr = color.NRGBA{192, 192, 192, 255}
+				} else {
+					if d.Absorbed > 0 {
+						msg += fmt.Sprintf(" (%d)", d.Absorbed)
+					}
+					if d.Critical {
+						msg += "!"
+						clr = color.NRGBA{255, 32, 32, 255}
+					}
+				}
+				state.kickers.Add(clgame.Kicker{
+					Message:  msg,
+					Position: o.GetPosition(),
+					Lifetime: 60 + i*10,
+					Color:    clr,
+				})
+			}
+			switch o := o.(type) {
+			case *game.Character:
+				o.TakeDamages(evt.Damages)
+				if o == state.Character() {
+					state.refreshStatbar(ctx)
+				}
+			case *game.Door:
+				o.TakeDamages(evt.Damages)
+			case *game.Bag:
+				o.TakeDamages(evt.Damages)
+			}
+		}
+	case game.EventLevelUp:
+		if ch := state.location.Character(evt.WID); ch != nil {
+			clr := color.NRGBA{255, 255, 255, 255}
+			switch evt.Attribute {
+			case game.AttributeSwole:
+				ch.Attributes.Swole = evt.Value
+				clr = game.ColorSwoleVibrant
+			case game.AttributeZooms:
+				ch.Attributes.Zooms = evt.Value
+				clr = game.ColorZoomsVibrant
+			case game.AttributeBrains:
+				ch.Attributes.Brains = evt.Value
+				clr = game.ColorBrainsVibrant
+			case game.AttributeFunk:
+				ch.Attributes.Funk = evt.Value
+				clr = game.ColorFunkVibrant
+			}
+			ch.Level = evt.Level
+			if ch == state.Character() {
+				state.refreshStatbar(ctx)
+			}
+			state.kickers.Add(clgame.Kicker{
+				Message:  fmt.Sprintf("+1 %s", evt.Attribute),
+				Position: ch.Position,
+				Lifetime: 90,
+				Color:    clr,
+			})
+		}
+	case game.EventStatusAdd:
+		if o := state.location.ObjectByWID(evt.Target); o != nil {
+			if h, ok := o.(statusHolder); ok {
+				h.SetStatus(evt.Status)
+			}
+			state.kickers.Add(clgame.Kicker{
+				Message:  evt.Status.Name,
+				Position: o.GetPosition(),
+				Lifetime: 60,
+				Color:    color.NRGBA{200, 100, 255, 255},
+			})
+			if o == state.Character() {
+				state.refreshStatbar(ctx)
+			}
+		}
+	case game.EventStatusTick:
+		if o := state.location.ObjectByWID(evt.Target); o != nil {
+			if h, ok := o.(statusHolder); ok {
+				h.SetStatus(evt.Status)
+			}
+			if o == state.Character() {
+				state.refreshStatbar(ctx)
+			}
+		}
+	case game.EventStatusExpire:
+		if o := state.location.ObjectByWID(evt.Target); o != nil {
+			if h, ok := o.(statusHolder); ok {
+				h.RemoveStatus(evt.Name)
+			}
+			state.kickers.Add(clgame.Kicker{
+				Message:  fmt.Sprintf("-%s", evt.Name),
+				Position: o.GetPosition(),
+				Lifetime: 60,
+				Color:    color.NRGBA{160, 160, 160, 255},
+			})
+			if o == state.Character() {
+				state.refreshStatbar(ctx)
+			}
+		}
 	case game.EventHealth:
 		if o := state.location.ObjectByWID(evt.Target); o != nil {
 			if ch := state.location.Character(evt.Target); ch != nil {
@@ -670,6 +1048,12 @@ func (state *Game) handleEvent(e game.Event, ctx ifs.RunContext) {
 	}
 }
 
+// statusHolder is an object that can hold statuses.
+type statusHolder interface {
+	SetStatus(s game.Status)
+	RemoveStatus(name string) bool
+}
+
 func (state *Game) sendDesire(wid id.WID, d game.Desire) error {
 	b, err := msgpack.Marshal(d)
 	if err != nil {
@@ -695,8 +1079,28 @@ func (state *Game) Character() *game.Character {
 	return nil
 }
 
+// toggleBag opens the bag with the given WID, or closes it if it is already open.
+func (state *Game) toggleBag(wid id.WID) {
+	if state.bag.WID == wid {
+		state.bag.Close()
+	} else {
+		state.bag.Open(wid)
+	}
+}
+
+// linkBagContents replaces a bag's inventory with the location's matching objects, as bag contents are sent as location objects as well.
+func linkBagContents(l *game.Location, o game.Object) {
+	if b, ok := o.(*game.Bag); ok {
+		for i, o2 := range b.Inventory {
+			if realObj := l.ObjectByWID(o2.GetWID()); realObj != nil {
+				b.Inventory[i] = realObj
+			}
+		}
+	}
+}
+
 func (state *Game) refreshInventory(ctx ifs.RunContext) {
-	state.inventory.Refresh(ctx, state.Character().Inventory)
+	state.inventory.Refresh(ctx, state.Character(), state.Character().Inventory)
 }
 
 func (state *Game) refreshStatbar(ctx ifs.RunContext) {
@@ -716,6 +1120,16 @@ func (state *Game) Draw(ctx ifs.DrawContext) {
 		cw := int(float64(ctx.Game.CellWidth) * ctx.Game.Zoom)
 		ch := int(float64(ctx.Game.CellHeight) * ctx.Game.Zoom)
 
+		// Work out the lighting and what our character can see so that remembered cells and objects can be dimmed.
+		lighting := state.location.Lighting(func(tid id.UUID) (game.TileArchetype, bool) {
+			t, ok := state.data.archetypes[tid].(game.TileArchetype)
+			return t, ok
+		})
+		var visible game.Visibility
+		if c := state.Character(); c != nil {
+			visible = state.location.Cells.FOV(c.Position, game.SightRadius).Lit(c.Position, lighting)
+		}
+
 		// TODO: Replace map access and cells with a client-centric cell wrapper that contains the ebiten.image ptr directly for efficiency.
 		// Draw tiles.
 		for x, col := range state.location.Cells {
@@ -732,12 +1146,13 @@ func (state *Game) Draw(ctx ifs.DrawContext) {
 
 				// This is kind of goofy, but modulate the color of the tile based on its position.
 				v := float32((x+y)%8) / 70.0
+				r, g, b := lightScale(visible, lighting, game.Position{X: x, Y: y})
 
 				if img := state.data.archetypeImages[*cell.TileID]; img != nil {
 					opts := ebiten.DrawImageOptions{}
 					opts.GeoM.Concat(scrollOpts)
 					opts.GeoM.Translate(float64(px), float64(py))
-					opts.ColorScale.Scale(1.0-v, 1.0-v, 1.0-v, 1.0)
+					opts.ColorScale.Scale((1.0-v)*r, (1.0-v)*g, (1.0-v)*b, 1.0)
 					ctx.Screen.DrawImage(img, &opts)
 				}
 			}
@@ -751,6 +1166,7 @@ func (state *Game) Draw(ctx ifs.DrawContext) {
 		// Draw pathing
 		if state.Character() != nil {
 			state.pather.Draw(ctx, state.Character())
+			state.targeter.Draw(ctx, state.Character())
 		}
 
 		// Draw characters
@@ -768,6 +1184,8 @@ func (state *Game) Draw(ctx ifs.DrawContext) {
 				opts := ebiten.DrawImageOptions{}
 				opts.GeoM.Concat(scrollOpts)
 				opts.GeoM.Translate(float64(px), float64(py))
+				r, g, b := lightScale(visible, lighting, pos)
+				opts.ColorScale.Scale(r, g, b, 1.0)
 				ctx.Screen.DrawImage(img, &opts)
 			}
 			switch o := o.(type) {
@@ -783,12 +1201,28 @@ func (state *Game) Draw(ctx ifs.DrawContext) {
 
 		state.sounds.Draw(ctx)
 		state.kickers.Draw(ctx)
+		state.projectiles.Draw(ctx)
 		state.pinger.Draw(ctx)
 	}
 
 	state.ui.Draw(ctx.Screen)
 }
 
+// rememberedBrightness is how bright remembered cells and objects that are not currently visible are drawn.
+const rememberedBrightness = 0.4
+
+// litBrightness is the least brightness of visible cells and objects, so that dimly lit surroundings can still be made out.
+const litBrightness = 0.5
+
+// lightScale returns the color scale to draw the given position with, tinted by its lighting if visible and dimmed if only remembered.
+func lightScale(visible game.Visibility, lighting game.Lighting, p game.Position) (float32, float32, float32) {
+	if !visible.Visible(p) {
+		return rememberedBrightness, rememberedBrightness, rememberedBrightness
+	}
+	i := lighting.At(p)
+	return float32(math.Max(i.R, litBrightness)), float32(math.Max(i.G, litBrightness)), float32(math.Max(i.B, litBrightness))
+}
+
 func ToFloat64(a int, b int) (float64, float64) {
 	return float64(a), float64(b)
 }
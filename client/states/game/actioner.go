@@ -44,6 +44,21 @@ func (actioner *Actioner) Update(binds Binds) game.Desire {
 	if binds.IsActionHeld("bash") == 0 {
 		return game.DesireBash{}
 	}
+	if binds.IsActionHeld("travel") == 0 {
+		return game.DesireTravel{}
+	}
+	if binds.IsActionHeld("lock") == 0 {
+		return game.DesireLock{}
+	}
+	if binds.IsActionHeld("unlock") == 0 {
+		return game.DesireUnlock{}
+	}
+	if binds.IsActionHeld("search") == 0 {
+		return game.DesireSearch{}
+	}
+	if binds.IsActionHeld("disarm") == 0 {
+		return game.DesireDisarm{}
+	}
 
 	return nil
 }
@@ -0,0 +1,227 @@
+package game
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"time"
+
+	eimage "github.com/ebitenui/ebitenui/image"
+	"github.com/ebitenui/ebitenui/widget"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/kettek/morogue/client/ifs"
+	"github.com/kettek/morogue/game"
+	"github.com/kettek/morogue/id"
+)
+
+// BagView shows the contents of an opened bag. Items can be dragged between it and the inventory.
+type BagView struct {
+	Data           Data
+	WID            id.WID // The opened bag, if any.
+	container      *widget.Container
+	innerContainer *widget.Container
+	title          *widget.Text
+	cells          []*bagCell
+	StashItem      func(wid id.WID, bag id.WID)
+	UnstashItem    func(wid id.WID)
+}
+
+type bagCell struct {
+	cell           *widget.Container
+	tooltip        *widget.ToolTip
+	tooltipContent *widget.Container
+	graphic        *widget.Graphic
+	WID            id.WID
+}
+
+func (bv *BagView) Init(container *widget.Container, ctx ifs.RunContext) {
+	bv.container = container
+
+	bv.innerContainer = widget.NewContainer(
+		widget.ContainerOpts.BackgroundImage(eimage.NewNineSliceColor(color.NRGBA{0x13, 0x1a, 0x22, 32})),
+		widget.ContainerOpts.Layout(widget.NewRowLayout(
+			widget.RowLayoutOpts.Direction(widget.DirectionVertical),
+			widget.RowLayoutOpts.Spacing(2),
+		)),
+		widget.ContainerOpts.WidgetOpts(
+			widget.WidgetOpts.MouseButtonPressedHandler(func(args *widget.WidgetMouseButtonPressedEventArgs) {
+				ctx.Game.PreventMapInput = true
+			}),
+			widget.WidgetOpts.MouseButtonReleasedHandler(func(args *widget.WidgetMouseButtonReleasedEventArgs) {
+				ctx.Game.PreventMapInput = false
+			}),
+		),
+	)
+
+	bv.title = widget.NewText(widget.TextOpts.Text("", ctx.UI.BodyCopyFace, color.White))
+	bv.innerContainer.AddChild(bv.title)
+
+	cellsContainer := widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewGridLayout(
+			widget.GridLayoutOpts.Columns(5),
+			widget.GridLayoutOpts.Spacing(2, 2),
+			widget.GridLayoutOpts.Stretch([]bool{true, true, true, true, true}, []bool{true, true}),
+		)),
+	)
+
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 2; j++ {
+			clickCount := 0
+			lastTime := time.Now()
+
+			tooltipContent := widget.NewContainer(
+				widget.ContainerOpts.BackgroundImage(ctx.UI.ItemInfoBackgroundImage),
+				widget.ContainerOpts.AutoDisableChildren(),
+				widget.ContainerOpts.Layout(widget.NewRowLayout(
+					widget.RowLayoutOpts.Direction(widget.DirectionVertical),
+					widget.RowLayoutOpts.Padding(widget.NewInsetsSimple(8)),
+				)),
+			)
+
+			tool := widget.NewToolTip(
+				widget.ToolTipOpts.Content(tooltipContent),
+				widget.ToolTipOpts.Delay(0),
+				widget.ToolTipOpts.Offset(image.Point{-1000, -1000}),
+				widget.ToolTipOpts.ContentOriginHorizontal(widget.TOOLTIP_ANCHOR_START),
+				widget.ToolTipOpts.ContentOriginVertical(widget.TOOLTIP_ANCHOR_START),
+			)
+			tool.Position = widget.TOOLTIP_POS_CURSOR_STICKY
+
+			graphic := widget.NewGraphic(
+				widget.GraphicOpts.Image(nil),
+				widget.GraphicOpts.WidgetOpts(
+					widget.WidgetOpts.LayoutData(
+						widget.RowLayoutPositionCenter,
+					),
+				),
+			)
+
+			bCell := &bagCell{}
+
+			cell := widget.NewContainer(
+				widget.ContainerOpts.BackgroundImage(cellBackgroundImage),
+				widget.ContainerOpts.Layout(widget.NewStackedLayout()),
+				widget.ContainerOpts.WidgetOpts(
+					widget.WidgetOpts.LayoutData(widget.GridLayoutData{
+						MaxWidth:           34,
+						MaxHeight:          34,
+						HorizontalPosition: widget.GridLayoutPositionEnd,
+						VerticalPosition:   widget.GridLayoutPositionStart,
+					}),
+					widget.WidgetOpts.ToolTip(tool),
+					widget.WidgetOpts.EnableDragAndDrop(
+						widget.NewDragAndDrop(
+							widget.DragAndDropOpts.ContentsCreater(makeDragWidget(ctx, dragContainer{cell: bCell, container: bv})),
+							widget.DragAndDropOpts.MinDragStartDistance(8),
+							widget.DragAndDropOpts.ContentsOriginVertical(widget.DND_ANCHOR_END),
+							widget.DragAndDropOpts.ContentsOriginHorizontal(widget.DND_ANCHOR_END),
+							widget.DragAndDropOpts.Offset(image.Point{16, 16}),
+						),
+					),
+					widget.WidgetOpts.CanDrop(func(args *widget.DragAndDropDroppedEventArgs) bool {
+						switch args.Data.(dragContainer).cell.(type) {
+						case *inventoryCell:
+							return true
+						}
+						return false
+					}),
+					widget.WidgetOpts.Dropped(func(args *widget.DragAndDropDroppedEventArgs) {
+						switch cell := args.Data.(dragContainer).cell.(type) {
+						case *inventoryCell:
+							if cell.WID != bv.WID {
+								bv.StashItem(cell.WID, bv.WID)
+							}
+						}
+					}),
+					widget.WidgetOpts.MouseButtonReleasedHandler(func(args *widget.WidgetMouseButtonReleasedEventArgs) {
+						if args.Inside && args.Button == ebiten.MouseButtonRight {
+							if time.Since(lastTime) > 500*time.Millisecond {
+								clickCount = 0
+								lastTime = time.Now()
+							}
+							clickCount++
+							if clickCount == 2 {
+								bv.UnstashItem(bCell.WID)
+								clickCount = 0
+								return
+							}
+						}
+
+						if args.Inside && args.Button == ebiten.MouseButtonLeft && ebiten.IsKeyPressed(ebiten.KeyControl) {
+							args.Widget.DragAndDrop.StartDrag()
+						}
+						if args.Button == ebiten.MouseButtonRight {
+							args.Widget.DragAndDrop.StopDrag()
+						}
+					}),
+				),
+			)
+
+			cell.AddChild(graphic)
+
+			bCell.cell = cell
+			bCell.tooltip = tool
+			bCell.tooltipContent = tooltipContent
+			bCell.graphic = graphic
+
+			bv.cells = append(bv.cells, bCell)
+
+			cellsContainer.AddChild(cell)
+		}
+	}
+
+	bv.innerContainer.AddChild(cellsContainer)
+	bv.container.AddChild(bv.innerContainer)
+
+	bv.Close()
+}
+
+// Open shows the bag with the given WID.
+func (bv *BagView) Open(wid id.WID) {
+	bv.WID = wid
+	bv.container.GetWidget().Visibility = widget.Visibility_Show
+}
+
+// Close hides the bag view.
+func (bv *BagView) Close() {
+	bv.WID = 0
+	bv.container.GetWidget().Visibility = widget.Visibility_Hide
+}
+
+// Refresh shows the contents of the given bag.
+func (bv *BagView) Refresh(ctx ifs.RunContext, character *game.Character, bag *game.Bag) {
+	// Clear old cells.
+	for _, cell := range bv.cells {
+		if cell.WID == 0 {
+			continue
+		}
+		cell.tooltip.Offset = image.Pt(-1000, -1000)
+		cell.graphic.Image = nil
+		cell.tooltipContent.RemoveChildren()
+		cell.WID = 0
+	}
+
+	title := bag.Name
+	if a, ok := bv.Data.Archetype(bag.ArchetypeID).(game.BagArchetype); ok && title == "" {
+		title = a.Title
+	}
+	bv.title.Label = fmt.Sprintf("%s %d/%d", title, len(bag.Inventory), bag.Capacity)
+	if bag.IsLocked() {
+		bv.title.Label += " (locked)"
+	}
+
+	// Refresh it.
+	for i, o := range bag.Inventory {
+		if i >= len(bv.cells) {
+			break
+		}
+		img := bv.Data.ArchetypeImage(o.GetArchetypeID())
+		bv.cells[i].WID = o.GetWID()
+		bv.cells[i].graphic.Image = img
+		bv.cells[i].tooltip.Offset = image.Pt(2, 2)
+
+		arch := bv.Data.Archetype(o.GetArchetypeID())
+		bv.cells[i].tooltipContent.RemoveChildren()
+		addObjectInfo(ctx, character, o, arch, bv.cells[i].tooltipContent)
+	}
+}
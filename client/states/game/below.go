@@ -193,7 +193,7 @@ func (below *Below) Update(ctx ifs.RunContext) game.Desire {
 	return nil
 }
 
-func (below *Below) Refresh(ctx ifs.RunContext, objects game.Objects) {
+func (below *Below) Refresh(ctx ifs.RunContext, character *game.Character, objects game.Objects) {
 	// Clear old cells.
 	for _, cell := range below.cells {
 		if cell.WID == 0 {
@@ -215,7 +215,7 @@ func (below *Below) Refresh(ctx ifs.RunContext, objects game.Objects) {
 
 		arch := below.Data.Archetype(o.GetArchetypeID())
 		below.cells[i].tooltipContent.RemoveChildren()
-		addObjectInfo(ctx, o, arch, below.cells[i].tooltipContent)
+		addObjectInfo(ctx, character, o, arch, below.cells[i].tooltipContent)
 
 		below.cells[i].indicator.Image = nil
 	}
@@ -30,7 +30,14 @@ func (b *Binds) Init() {
 	b.SetActionKeys("move-up", []ebiten.Key{ebiten.KeyArrowUp, ebiten.KeyW, ebiten.KeyK})
 	b.SetActionKeys("move-down", []ebiten.Key{ebiten.KeyArrowDown, ebiten.KeyS, ebiten.KeyJ})
 	b.SetActionKeys("bash", []ebiten.Key{ebiten.KeyB})
+	b.SetActionKeys("shoot", []ebiten.Key{ebiten.KeyF})
+	b.SetActionKeys("throw", []ebiten.Key{ebiten.KeyT})
 	b.SetActionKeys("pickup", []ebiten.Key{ebiten.KeyComma})
+	b.SetActionKeys("travel", []ebiten.Key{ebiten.KeyEnter, ebiten.KeyNumpadEnter})
+	b.SetActionKeys("lock", []ebiten.Key{ebiten.KeyY})
+	b.SetActionKeys("unlock", []ebiten.Key{ebiten.KeyU})
+	b.SetActionKeys("search", []ebiten.Key{ebiten.KeyE})
+	b.SetActionKeys("disarm", []ebiten.Key{ebiten.KeyX})
 	b.SetActionKeys("lock-camera", []ebiten.Key{ebiten.KeyC})
 	b.SetActionKeys("snap-camera", []ebiten.Key{ebiten.KeySpace})
 	b.SetActionKeys("toggle-grid", []ebiten.Key{ebiten.KeyG})
@@ -57,6 +57,8 @@ func (w *dragWidget) Create(parent widget.HasWidget) (*widget.Container, interfa
 			w.graphic.Image = cell.graphic.Image
 		case *belowCell:
 			w.graphic.Image = cell.graphic.Image
+		case *bagCell:
+			w.graphic.Image = cell.graphic.Image
 		}
 	}
 
@@ -22,6 +22,7 @@ type Inventory struct {
 	ApplyItem      func(wid id.WID, apply bool)
 	DropItem       func(wid id.WID)
 	PickupItem     func(wid id.WID)
+	UnstashItem    func(wid id.WID)
 }
 
 type inventoryCell struct {
@@ -139,6 +140,8 @@ func (inv *Inventory) Init(container *widget.Container, ctx ifs.RunContext) {
 							return true
 						case *belowCell:
 							return true
+						case *bagCell:
+							return true
 						}
 						return false
 					}),
@@ -148,6 +151,8 @@ func (inv *Inventory) Init(container *widget.Container, ctx ifs.RunContext) {
 							// TODO: Reorganize inventory?
 						case *belowCell:
 							inv.PickupItem(cell.WID)
+						case *bagCell:
+							inv.UnstashItem(cell.WID)
 						}
 					}),
 					widget.WidgetOpts.MouseButtonReleasedHandler(func(args *widget.WidgetMouseButtonReleasedEventArgs) {
@@ -205,7 +210,7 @@ func (inv *Inventory) Init(container *widget.Container, ctx ifs.RunContext) {
 	inv.container.AddChild(inv.innerContainer)
 }
 
-func (inv *Inventory) Refresh(ctx ifs.RunContext, objects game.Objects) {
+func (inv *Inventory) Refresh(ctx ifs.RunContext, character *game.Character, objects game.Objects) {
 	// TODO: Don't clear cells that have remained the same.
 	// Clear old cells.
 	for _, cell := range inv.cells {
@@ -229,7 +234,7 @@ func (inv *Inventory) Refresh(ctx ifs.RunContext, objects game.Objects) {
 
 		arch := inv.Data.Archetype(o.GetArchetypeID())
 		inv.cells[i].tooltipContent.RemoveChildren()
-		addObjectInfo(ctx, o, arch, inv.cells[i].tooltipContent)
+		addObjectInfo(ctx, character, o, arch, inv.cells[i].tooltipContent)
 
 		inv.cells[i].indicator.Image = nil
 		switch o := o.(type) {
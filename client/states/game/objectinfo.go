@@ -3,6 +3,7 @@ package game
 import (
 	"fmt"
 	"image/color"
+	"strings"
 
 	eimage "github.com/ebitenui/ebitenui/image"
 	"github.com/ebitenui/ebitenui/widget"
@@ -55,12 +56,66 @@ func makeDescription(ctx ifs.RunContext, txt string) *widget.TextArea {
 	)
 }
 
-func addObjectInfo(ctx ifs.RunContext, object game.Object, arch game.Archetype, container *widget.Container) {
+// weaponRangeString returns the damage range the character would do with the given weapon, or the archetype's own range if there is no character.
+func weaponRangeString(c *game.Character, object game.Object, a game.WeaponArchetype) string {
+	if c == nil || c.Archetype == nil {
+		return a.RangeString()
+	}
+	w := game.Weapon{Objectable: game.Objectable{Archetype: a}}
+	if o, ok := object.(*game.Weapon); ok {
+		w.WID = o.WID
+	}
+	damages := []game.Damage{game.WeaponDamage(c, &w, !a.Slots.HasSlot(game.SlotMainHand))}
+	for _, t := range c.Archetype.(game.CharacterArchetype).Traits {
+		damages = t.AdjustDamages(damages)
+	}
+	return damages[0].RangeString()
+}
+
+// lockedText returns the text shown for locked objects.
+func lockedText(ctx ifs.RunContext) *widget.Text {
+	return widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text("locked", ctx.UI.BodyCopyFace, color.NRGBA{R: 250, G: 200, B: 50, A: 255}))
+}
+
+// healthText returns the text shown for the health of breakable objects.
+func healthText(ctx ifs.RunContext, h *game.Hurtable) *widget.Text {
+	return widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%s health", h.String()), ctx.UI.BodyCopyFace, color.NRGBA{R: 200, G: 200, B: 200, A: 255}))
+}
+
+// effectsString returns a short summary of the effects of using an item.
+func effectsString(effects game.Effects) string {
+	var parts []string
+	for _, e := range effects {
+		switch e.Kind {
+		case game.EffectHeal:
+			parts = append(parts, fmt.Sprintf("heals %d", e.Amount))
+		case game.EffectCure:
+			if e.Name == "" {
+				parts = append(parts, "cures ailments")
+			} else {
+				parts = append(parts, fmt.Sprintf("cures %s", e.Name))
+			}
+		case game.EffectTeleport:
+			parts = append(parts, "teleports")
+		case game.EffectRevealMap:
+			parts = append(parts, "reveals the map")
+		case game.EffectStatus:
+			parts = append(parts, fmt.Sprintf("grants %s", e.Status.Name))
+		case game.EffectSpawn:
+			parts = append(parts, "summons")
+		case game.EffectIdentify:
+			parts = append(parts, "identifies")
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func addObjectInfo(ctx ifs.RunContext, character *game.Character, object game.Object, arch game.Archetype, container *widget.Container) {
 	switch a := arch.(type) {
 	case game.WeaponArchetype:
 
 		title := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%s", a.Title), ctx.UI.BodyCopyFace, color.White))
-		values := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%s %s", a.RangeString(), a.WeaponType), ctx.UI.BodyCopyFace, a.WeaponType.Color()))
+		values := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%s %s", weaponRangeString(character, object, a), a.WeaponType), ctx.UI.BodyCopyFace, a.WeaponType.Color()))
 		slots := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%s", a.Slots.String()), ctx.UI.BodyCopyFace, color.NRGBA{R: 200, G: 200, B: 200, A: 255}))
 		desc := makeDescription(ctx, a.Description)
 
@@ -134,8 +189,86 @@ func addObjectInfo(ctx ifs.RunContext, object game.Object, arch game.Archetype,
 		container.AddChild(title)
 		container.AddChild(foodLine)
 		container.AddChild(desc)
+	case game.BagArchetype:
+		title := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%s", a.Title), ctx.UI.BodyCopyFace, color.White))
+		desc := makeDescription(ctx, a.Description)
+
+		container.AddChild(title)
+		if o, ok := object.(*game.Bag); ok {
+			contents := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%d/%d items", len(o.Inventory), a.Capacity), ctx.UI.BodyCopyFace, color.NRGBA{R: 200, G: 200, B: 200, A: 255}))
+			container.AddChild(contents)
+			if o.IsLocked() {
+				container.AddChild(lockedText(ctx))
+			}
+			if o.CanBreak() {
+				container.AddChild(healthText(ctx, &o.Hurtable))
+			}
+		}
+		container.AddChild(desc)
+	case game.DoorArchetype:
+		title := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%s", a.Title), ctx.UI.BodyCopyFace, color.White))
+
+		container.AddChild(title)
+		if o, ok := object.(*game.Door); ok {
+			if o.IsLocked() {
+				container.AddChild(lockedText(ctx))
+			}
+			if o.CanBreak() {
+				container.AddChild(healthText(ctx, &o.Hurtable))
+			}
+		}
+	case game.KeyArchetype:
+		title := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%s", a.Title), ctx.UI.BodyCopyFace, color.White))
+		desc := makeDescription(ctx, a.Description)
+
+		container.AddChild(title)
+		if a.Lockpick && character != nil && character.Archetype != nil {
+			chance := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%d%% to pick an easy lock", int(game.PickChance(character, 0)*100)), ctx.UI.BodyCopyFace, color.NRGBA{R: 200, G: 200, B: 200, A: 255}))
+			container.AddChild(chance)
+		}
+		container.AddChild(desc)
+	case game.TrapArchetype:
+		title := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%s", a.Title), ctx.UI.BodyCopyFace, color.White))
+		desc := makeDescription(ctx, a.Description)
+
+		container.AddChild(title)
+		if o, ok := object.(*game.Trap); ok && !o.Armed() {
+			disarmed := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text("disarmed", ctx.UI.BodyCopyFace, color.NRGBA{R: 200, G: 200, B: 200, A: 255}))
+			container.AddChild(disarmed)
+		} else if character != nil && character.Archetype != nil {
+			chance := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%d%% to disarm", int(game.TrapDisarmChance(character, a.Difficulty)*100)), ctx.UI.BodyCopyFace, color.NRGBA{R: 200, G: 200, B: 200, A: 255}))
+			container.AddChild(chance)
+		}
+		container.AddChild(desc)
+	case game.StairsArchetype:
+		title := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%s", a.Title), ctx.UI.BodyCopyFace, color.White))
+		desc := makeDescription(ctx, a.Description)
+
+		container.AddChild(title)
+		container.AddChild(desc)
 	case game.ItemArchetype:
-		// TODO
+		titleText := a.Title
+		o, _ := object.(*game.Item)
+		if o != nil {
+			titleText = o.Title()
+		}
+		title := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%s", titleText), ctx.UI.BodyCopyFace, color.White))
+		container.AddChild(title)
+		if a.Usable() && (o == nil || o.Identified || a.Unidentified == "") {
+			if a.Charges > 0 {
+				charges := a.Charges
+				if o != nil {
+					charges = o.Charges
+				}
+				chargesText := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%d/%d charges", charges, a.Charges), ctx.UI.BodyCopyFace, color.NRGBA{R: 200, G: 200, B: 200, A: 255}))
+				container.AddChild(chargesText)
+			}
+			effects := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(effectsString(a.Effects), ctx.UI.BodyCopyFace, color.NRGBA{R: 200, G: 200, B: 200, A: 255}))
+			container.AddChild(effects)
+		}
+		if a.Description != "" && (o == nil || o.Identified || a.Unidentified == "") {
+			container.AddChild(makeDescription(ctx, a.Description))
+		}
 	}
 
 }
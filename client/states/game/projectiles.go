@@ -0,0 +1,72 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/kettek/morogue/client/ifs"
+	"github.com/kettek/morogue/game"
+)
+
+// Projectile is a shot or thrown object in flight.
+type Projectile struct {
+	From, To game.Position
+	Image    *ebiten.Image // Image to draw in flight. If nil, a small bolt is drawn instead.
+	elapsed  int
+	duration int
+}
+
+// Projectiles animates projectiles travelling between cells.
+type Projectiles struct {
+	projectiles      []*Projectile
+	offsetX, offsetY int
+}
+
+// SetOffset sets the current visual offset of the projectiles.
+func (projectiles *Projectiles) SetOffset(x, y int) {
+	projectiles.offsetX = x
+	projectiles.offsetY = y
+}
+
+// Add adds a projectile to animate. Its flight time is based upon the distance travelled.
+func (projectiles *Projectiles) Add(projectile Projectile) {
+	projectile.duration = 2 + projectile.From.Distance(projectile.To)*2
+	projectiles.projectiles = append(projectiles.projectiles, &projectile)
+}
+
+// Update advances projectiles and removes those that have landed.
+func (projectiles *Projectiles) Update() {
+	i := 0
+	for _, p := range projectiles.projectiles {
+		p.elapsed++
+		if p.elapsed < p.duration {
+			projectiles.projectiles[i] = p
+			i++
+		}
+	}
+	for j := i; j < len(projectiles.projectiles); j++ {
+		projectiles.projectiles[j] = nil
+	}
+	projectiles.projectiles = projectiles.projectiles[:i]
+}
+
+// Draw draws the projectiles to the provided context's screen.
+func (projectiles *Projectiles) Draw(ctx ifs.DrawContext) {
+	cw := float64(ctx.Game.CellWidth) * ctx.Game.Zoom
+	ch := float64(ctx.Game.CellHeight) * ctx.Game.Zoom
+	for _, p := range projectiles.projectiles {
+		t := float64(p.elapsed) / float64(p.duration)
+		x := (float64(p.From.X) + float64(p.To.X-p.From.X)*t) * cw
+		y := (float64(p.From.Y) + float64(p.To.Y-p.From.Y)*t) * ch
+		x += float64(projectiles.offsetX)
+		y += float64(projectiles.offsetY)
+		if p.Image != nil {
+			opts := ebiten.DrawImageOptions{}
+			opts.GeoM.Translate(x, y)
+			ctx.Screen.DrawImage(p.Image, &opts)
+		} else {
+			vector.DrawFilledCircle(ctx.Screen, float32(x+cw/2), float32(y+ch/2), float32(cw/8), color.NRGBA{230, 230, 200, 255}, true)
+		}
+	}
+}
@@ -10,15 +10,19 @@ import (
 	"github.com/kettek/morogue/client/embed"
 	"github.com/kettek/morogue/client/ifs"
 	"github.com/kettek/morogue/game"
+	"github.com/kettek/morogue/locale"
 )
 
 type Statbar struct {
-	container        *widget.Container
-	innerContainer   *widget.Container
-	armorsContainer  *widget.Container
-	damagesContainer *widget.Container
-	healthContainer  *widget.Container
-	hungerContainer  *widget.Container
+	container         *widget.Container
+	innerContainer    *widget.Container
+	armorsContainer   *widget.Container
+	damagesContainer  *widget.Container
+	healthContainer   *widget.Container
+	hungerContainer   *widget.Container
+	loadContainer     *widget.Container
+	statusesContainer *widget.Container
+	modeContainer     *widget.Container
 }
 
 func (hb *Statbar) Init(container *widget.Container, ctx ifs.RunContext) {
@@ -96,6 +100,45 @@ func (hb *Statbar) Init(container *widget.Container, ctx ifs.RunContext) {
 	)
 	hb.innerContainer.AddChild(hb.hungerContainer)
 
+	hb.loadContainer = widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewRowLayout(
+			widget.RowLayoutOpts.Direction(widget.DirectionHorizontal),
+		)),
+		widget.ContainerOpts.WidgetOpts(
+			widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+				StretchHorizontal:  false,
+				HorizontalPosition: widget.AnchorLayoutPositionStart,
+			}),
+		),
+	)
+	hb.innerContainer.AddChild(hb.loadContainer)
+
+	hb.statusesContainer = widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewRowLayout(
+			widget.RowLayoutOpts.Direction(widget.DirectionHorizontal),
+		)),
+		widget.ContainerOpts.WidgetOpts(
+			widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+				StretchHorizontal:  false,
+				HorizontalPosition: widget.AnchorLayoutPositionStart,
+			}),
+		),
+	)
+	hb.innerContainer.AddChild(hb.statusesContainer)
+
+	hb.modeContainer = widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewRowLayout(
+			widget.RowLayoutOpts.Direction(widget.DirectionHorizontal),
+		)),
+		widget.ContainerOpts.WidgetOpts(
+			widget.WidgetOpts.LayoutData(widget.AnchorLayoutData{
+				StretchHorizontal:  false,
+				HorizontalPosition: widget.AnchorLayoutPositionStart,
+			}),
+		),
+	)
+	hb.innerContainer.AddChild(hb.modeContainer)
+
 	hb.container.AddChild(hb.innerContainer)
 }
 
@@ -213,7 +256,6 @@ func (hb *Statbar) Refresh(ctx ifs.RunContext, c *game.Character, a game.Charact
 			c.Hungerable.CalculateFromCharacter(c)
 
 			hb.hungerContainer.RemoveChildren()
-			// TODO: Implement hunger
 			container := widget.NewContainer(
 				widget.ContainerOpts.Layout(widget.NewRowLayout(
 					widget.RowLayoutOpts.Direction(widget.DirectionHorizontal),
@@ -230,7 +272,11 @@ func (hb *Statbar) Refresh(ctx ifs.RunContext, c *game.Character, a game.Charact
 				maxText = maxText + " "
 			}
 
-			value := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%s/%s", minText, maxText), ctx.UI.BodyCopyFace, color.RGBA{255, 255, 32, 255}))
+			text := fmt.Sprintf("%s/%s", minText, maxText)
+			if c.HungerState != game.HungerStateFed {
+				text += " " + c.HungerState.String()
+			}
+			value := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(text, ctx.UI.BodyCopyFace, c.HungerState.Color()))
 
 			graphic := widget.NewGraphic(
 				widget.GraphicOpts.Image(embed.IconHunger),
@@ -246,5 +292,61 @@ func (hb *Statbar) Refresh(ctx ifs.RunContext, c *game.Character, a game.Charact
 
 			hb.hungerContainer.AddChild(container)
 		}
+
+		{
+			hb.loadContainer.RemoveChildren()
+			container := widget.NewContainer(
+				widget.ContainerOpts.Layout(widget.NewRowLayout(
+					widget.RowLayoutOpts.Direction(widget.DirectionHorizontal),
+					widget.RowLayoutOpts.Padding(widget.Insets{Left: 8, Right: 8}),
+				)),
+			)
+
+			text := fmt.Sprintf("%d/%d", c.Load(), c.CarryCapacity())
+			if c.Encumbrance != game.EncumbranceNone {
+				text += " " + c.Encumbrance.String()
+			}
+			value := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(text, ctx.UI.BodyCopyFace, c.Encumbrance.Color()))
+
+			container.AddChild(value)
+
+			hb.loadContainer.AddChild(container)
+		}
+
+		hb.statusesContainer.RemoveChildren()
+		for _, status := range c.Statuses {
+			container := widget.NewContainer(
+				widget.ContainerOpts.Layout(widget.NewRowLayout(
+					widget.RowLayoutOpts.Direction(widget.DirectionHorizontal),
+					widget.RowLayoutOpts.Padding(widget.Insets{Left: 8, Right: 8}),
+				)),
+			)
+			value := widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(fmt.Sprintf("%s %d", status.Name, status.Duration), ctx.UI.BodyCopyFace, color.RGBA{200, 100, 255, 255}))
+			container.AddChild(value)
+			hb.statusesContainer.AddChild(container)
+		}
 	}
 }
+
+// RefreshMode shows whether the location is in real-time or turn-based mode.
+func (hb *Statbar) RefreshMode(ctx ifs.RunContext, lc locale.Localizer, inTurns bool, turn int) {
+	hb.modeContainer.RemoveChildren()
+
+	container := widget.NewContainer(
+		widget.ContainerOpts.Layout(widget.NewRowLayout(
+			widget.RowLayoutOpts.Direction(widget.DirectionHorizontal),
+			widget.RowLayoutOpts.Padding(widget.Insets{Left: 8, Right: 8}),
+		)),
+	)
+
+	text := lc.T("real-time")
+	clr := color.RGBA{128, 128, 128, 255}
+	if inTurns {
+		text = fmt.Sprintf("%s %d", lc.T("turn"), turn)
+		clr = color.RGBA{255, 128, 32, 255}
+	}
+
+	container.AddChild(widget.NewText(widget.TextOpts.ProcessBBCode(true), widget.TextOpts.Text(text, ctx.UI.BodyCopyFace, clr)))
+
+	hb.modeContainer.AddChild(container)
+}
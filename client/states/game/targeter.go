@@ -0,0 +1,102 @@
+package game
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/kettek/morogue/client/ifs"
+	"github.com/kettek/morogue/game"
+	"github.com/kettek/morogue/id"
+)
+
+// TargetKind is the kind of action a target is being picked for.
+type TargetKind int
+
+// Our target kinds.
+const (
+	TargetKindNone TargetKind = iota
+	TargetKindShoot
+	TargetKindThrow
+)
+
+// Targeter provides a targeting mode where the player picks a cell with the mouse to shoot or throw at.
+type Targeter struct {
+	offsetX, offsetY int
+	kind             TargetKind
+	wid              id.WID
+	x, y             int
+	Target           func(kind TargetKind, wid id.WID, x, y int)
+}
+
+// SetOffset sets the targeter's visual offset.
+func (targeter *Targeter) SetOffset(x, y int) {
+	targeter.offsetX = x
+	targeter.offsetY = y
+}
+
+// Begin starts targeting for the given kind and object.
+func (targeter *Targeter) Begin(kind TargetKind, wid id.WID) {
+	targeter.kind = kind
+	targeter.wid = wid
+}
+
+// Cancel stops targeting.
+func (targeter *Targeter) Cancel() {
+	targeter.kind = TargetKindNone
+	targeter.wid = 0
+}
+
+// Active returns if the targeter is currently targeting.
+func (targeter *Targeter) Active() bool {
+	return targeter.kind != TargetKindNone
+}
+
+// Update tracks the hovered cell and calls Target when the player clicks. Escape or the right mouse button cancels targeting.
+func (targeter *Targeter) Update(ctx ifs.RunContext) {
+	if !targeter.Active() {
+		return
+	}
+	cw := int(float64(ctx.Game.CellWidth) * ctx.Game.Zoom)
+	ch := int(float64(ctx.Game.CellHeight) * ctx.Game.Zoom)
+	x, y := ebiten.CursorPosition()
+	x -= targeter.offsetX
+	y -= targeter.offsetY
+	if x >= 0 && y >= 0 {
+		targeter.x = x / cw
+		targeter.y = y / ch
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
+		targeter.Cancel()
+		return
+	}
+	if !ctx.Game.PreventMapInput && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if targeter.Target != nil {
+			targeter.Target(targeter.kind, targeter.wid, targeter.x, targeter.y)
+		}
+		targeter.Cancel()
+	}
+}
+
+// Draw draws the line of fire from the character to the hovered cell.
+func (targeter *Targeter) Draw(ctx ifs.DrawContext, character *game.Character) {
+	if !targeter.Active() {
+		return
+	}
+	cw := float32(float64(ctx.Game.CellWidth) * ctx.Game.Zoom)
+	ch := float32(float64(ctx.Game.CellHeight) * ctx.Game.Zoom)
+	clr := color.NRGBA{255, 200, 50, 100}
+	if targeter.kind == TargetKindShoot {
+		clr = color.NRGBA{50, 250, 50, 100}
+	}
+	for _, p := range game.Line(character.Position, game.Position{X: targeter.x, Y: targeter.y}) {
+		x := float32(p.X)*cw + float32(targeter.offsetX)
+		y := float32(p.Y)*ch + float32(targeter.offsetY)
+		vector.DrawFilledRect(ctx.Screen, x, y, cw, ch, clr, false)
+	}
+	x := float32(targeter.x)*cw + float32(targeter.offsetX)
+	y := float32(targeter.y)*ch + float32(targeter.offsetY)
+	vector.StrokeRect(ctx.Screen, x, y, cw, ch, 2, color.NRGBA{255, 255, 255, 200}, false)
+}
@@ -31,6 +31,10 @@ func run() error {
 	log.Printf("listening on http://%v", l.Addr())
 
 	data := &server.Data{}
+	if err := data.LoadTraits(); err != nil {
+		return err
+	}
+	log.Println(len(data.Traits), "traits")
 	if err := data.LoadArchetypes(); err != nil {
 		return err
 	}
@@ -43,14 +47,28 @@ func run() error {
 		return err
 	}
 	log.Println(len(data.Fixtures), "fixtures")
+	if err := data.LoadLootTables(); err != nil {
+		return err
+	}
+	log.Println(len(data.LootTables), "loot tables")
 
-	accounts, err := server.NewAccounts("accounts")
+	db, err := server.OpenDatabase("accounts")
 	if err != nil {
 		return err
 	}
+	defer db.Close()
 
-	u, clientChan, checkChan := server.NewUniverse(accounts, data)
-	u.Run()
+	accounts, err := server.NewAccounts(db)
+	if err != nil {
+		return err
+	}
+	snapshots, err := server.NewSnapshots(db)
+	if err != nil {
+		return err
+	}
+
+	u, clientChan, checkChan := server.NewUniverse(accounts, snapshots, data)
+	closeCh := u.Run()
 
 	ps := server.NewSocketServer(clientChan, checkChan)
 
@@ -77,5 +95,15 @@ func run() error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 
-	return s.Shutdown(ctx)
+	err = s.Shutdown(ctx)
+
+	// Stop the universe so the worlds are saved.
+	closeCh <- struct{}{}
+	select {
+	case <-closeCh:
+	case <-ctx.Done():
+		log.Println("timed out saving worlds")
+	}
+
+	return err
 }
@@ -97,6 +97,27 @@ func DecodeArchetype(bytes []byte, rootPath string) (Archetype, error) {
 		}
 		a.Image = path.Join(rootPath, a.Image)
 		return a, nil
+	case id.KeyStairs:
+		var a StairsArchetype
+		if err = json.Unmarshal(bytes, &a); err != nil {
+			return nil, err
+		}
+		a.Image = path.Join(rootPath, a.Image)
+		return a, nil
+	case id.KeyKey:
+		var a KeyArchetype
+		if err = json.Unmarshal(bytes, &a); err != nil {
+			return nil, err
+		}
+		a.Image = path.Join(rootPath, a.Image)
+		return a, nil
+	case id.KeyTrap:
+		var a TrapArchetype
+		if err = json.Unmarshal(bytes, &a); err != nil {
+			return nil, err
+		}
+		a.Image = path.Join(rootPath, a.Image)
+		return a, nil
 	default:
 		return nil, fmt.Errorf("invalid archetype type: %s", key)
 	}
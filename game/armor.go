@@ -78,6 +78,9 @@ type ArmorArchetype struct {
 	MaxArmor    int
 	MovePenalty int   // Penalty to movement speed.
 	Slots       Slots `msgpack:"S,omitempty"`
+	Tags        Tags  `msgpack:"t,omitempty"` // Kinds the armor is considered as, such as "hide".
+	Weight      int   `msgpack:"W,omitempty"` // Weight when carried.
+	Light       Light `msgpack:"L,omitempty"` // Light emitted by the armor when equipped or on the ground.
 }
 
 // Type returns the type of the archetype.
@@ -90,6 +93,16 @@ func (a ArmorArchetype) GetID() id.UUID {
 	return a.ID
 }
 
+// GetLight returns the light emitted.
+func (a ArmorArchetype) GetLight() Light {
+	return a.Light
+}
+
+// GetWeight returns the weight.
+func (a ArmorArchetype) GetWeight() int {
+	return a.Weight
+}
+
 // RangeString returns the armor range of the archetype.
 func (a ArmorArchetype) RangeString() string {
 	if a.MinArmor == 0 {
@@ -6,8 +6,10 @@ import "image/color"
 type Attribute uint8
 
 const (
+	// AttributeNone represents no attribute
+	AttributeNone Attribute = iota
 	// AttributeSwole represents physicality
-	AttributeSwole Attribute = iota
+	AttributeSwole
 	// AttributeZooms represents speed
 	AttributeZooms
 	// AttributeBrains represents intelligence
@@ -16,6 +18,39 @@ const (
 	AttributeFunk
 )
 
+// String returns the string representation of the attribute.
+func (a Attribute) String() string {
+	switch a {
+	case AttributeSwole:
+		return lc.T("swole")
+	case AttributeZooms:
+		return lc.T("zooms")
+	case AttributeBrains:
+		return lc.T("brains")
+	case AttributeFunk:
+		return lc.T("funk")
+	default:
+		return ""
+	}
+}
+
+// UnmarshalJSON unmarshals the JSON representation of the attribute.
+func (a *Attribute) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case `"swole"`:
+		*a = AttributeSwole
+	case `"zooms"`:
+		*a = AttributeZooms
+	case `"brains"`:
+		*a = AttributeBrains
+	case `"funk"`:
+		*a = AttributeFunk
+	default:
+		*a = AttributeNone
+	}
+	return nil
+}
+
 // Our attribute descriptions.
 const (
 	AttributeSwoleDescription  = "Swole determines damage and health"
@@ -5,11 +5,16 @@ import "github.com/kettek/morogue/id"
 // BagArchetype is for supporting our baggage.
 type BagArchetype struct {
 	ID          id.UUID
-	Title       string `msgpack:"T,omitempty"`
-	Description string `msgpack:"d,omitempty"`
-	Image       string `msgpack:"i,omitempty"`
-	Capacity    int    `msgpack:"c,omitempty"`
-	Limit       int    `msgpack:"l,omitempty"`
+	Title       string  `msgpack:"T,omitempty"`
+	Description string  `msgpack:"d,omitempty"`
+	Image       string  `msgpack:"i,omitempty"`
+	Capacity    int     `msgpack:"c,omitempty"`
+	Limit       int     `msgpack:"l,omitempty"`
+	Weight      int     `msgpack:"W,omitempty"` // Weight when carried.
+	MaxHealth   int     `msgpack:"-"`           // Bags with health can be bashed open.
+	Debris      id.UUID `msgpack:"-"`           // Archetype left behind when broken, if any.
+	Loot        id.UUID `msgpack:"-"`           // Loot table the bag is filled from when generated, if any.
+	Fixed       bool    `msgpack:"-"`           // Fixed bags, such as chests, can't be picked up.
 }
 
 // Type returns "bag".
@@ -22,15 +27,126 @@ func (a BagArchetype) GetID() id.UUID {
 	return a.ID
 }
 
+// GetWeight returns the weight.
+func (a BagArchetype) GetWeight() int {
+	return a.Weight
+}
+
 // Bag represents a bag object in the world.
 type Bag struct {
 	Objectable
 	Position
 	Containerable
+	Lockable
+	Hurtable
 	Name string `msgpack:"n,omitempty"`
 }
 
 // Type returns "bag"
-func (o Bag) Type() ObjectType {
+func (o *Bag) Type() ObjectType {
 	return "bag"
 }
+
+// CanBreak returns true if the bag has health to lose.
+func (o *Bag) CanBreak() bool {
+	return o.MaxHealth > 0
+}
+
+// IsBroken returns true if the bag has been bashed out of health.
+func (o *Bag) IsBroken() bool {
+	return o.CanBreak() && o.Health <= 0
+}
+
+// SetWID sets the WID of the bag. This also sets the bag's container WID and that of its contents, so they refer to the bag.
+func (o *Bag) SetWID(wid id.WID) {
+	o.WID = wid
+	o.ContainerWID = wid
+	for _, item := range o.Inventory {
+		item.SetContainerWID(wid)
+	}
+}
+
+// Reachable returns true if the bag is in the character's inventory or beneath them.
+func (o *Bag) Reachable(c *Character) bool {
+	return c.InInventory(o.WID) || (o.GetContainerWID() == 0 && o.Position == c.Position)
+}
+
+// Stash moves an object from the character's inventory into the bag. The bag must be in the character's inventory or beneath them.
+func (c *Character) Stash(o Object, b *Bag) Event {
+	if !c.InInventory(o.GetWID()) {
+		return EventNotice{
+			Message: lc.T("You don't have that item."),
+		}
+	}
+	if _, ok := o.(*Bag); ok {
+		return EventNotice{
+			Message: lc.T("You can't put a bag in a bag."),
+		}
+	}
+	if !b.Reachable(c) {
+		return EventNotice{
+			Message: lc.T("You can't reach that."),
+		}
+	}
+	if b.IsLocked() {
+		return EventNotice{
+			Message: lc.T("It's locked."),
+		}
+	}
+	if err := b.CanHold(o); err != nil {
+		if err == ErrContainerFull {
+			return EventNotice{
+				Message: lc.T("There's no room for that."),
+			}
+		}
+		return EventNotice{
+			Message: lc.T("That's too heavy for it to hold."),
+		}
+	}
+
+	// Drop takes care of unapplying the object.
+	c.Drop(o)
+	b.Pickup(o)
+
+	return EventStash{
+		Stasher: c.WID,
+		WID:     o.GetWID(),
+		Bag:     b.WID,
+	}
+}
+
+// Unstash moves an object from the bag into the character's inventory. The bag must be in the character's inventory or beneath them.
+func (c *Character) Unstash(o Object, b *Bag) Event {
+	if !b.InInventory(o.GetWID()) {
+		return EventNotice{
+			Message: lc.T("That isn't in there."),
+		}
+	}
+	if !b.Reachable(c) {
+		return EventNotice{
+			Message: lc.T("You can't reach that."),
+		}
+	}
+	if b.IsLocked() {
+		return EventNotice{
+			Message: lc.T("It's locked."),
+		}
+	}
+	// Taking something out of a carried bag doesn't change the load.
+	if !c.InInventory(b.WID) && !c.CanCarry(o) {
+		return EventNotice{
+			Message: lc.T("You can't carry that much."),
+		}
+	}
+
+	b.Drop(o)
+	c.Inventory = append(c.Inventory, o)
+	o.SetContainerWID(c.WID)
+	o.SetPosition(Position{-1, -1})
+
+	return EventUnstash{
+		Unstasher: c.WID,
+		WID:       o.GetWID(),
+		Bag:       b.WID,
+	}
+}
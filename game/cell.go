@@ -11,6 +11,7 @@ import (
 type Cell struct {
 	TileID  *id.UUID     `msgpack:"id,omitempty"` // The Tile ID of the cell.
 	Blocks  MovementType `msgpack:"b,omitempty"`  // Whether the cell blocks. This should be generated from the TileID and the contained Objects.
+	Opaque  bool         `msgpack:"O,omitempty"`  // Whether the cell blocks sight. Like Blocks, this is generated from the TileID and the contained Objects.
 	Objects Objects      `msgpack:"o,omitempty"`  // Non-thinking/active objects. These will generally be weapons, armor, gold, food, etc.
 	//
 	value int       `msgpack:"-"`
@@ -81,6 +82,25 @@ const (
 	MovementFly
 )
 
+// UnmarshalJSON unmarshals a string into our MovementType.
+func (m *MovementType) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `"all"`:
+		*m = MovementAll
+	case `"walk"`:
+		*m = MovementWalk
+	case `"swim"`:
+		*m = MovementSwim
+	case `"hover"`:
+		*m = MovementHover
+	case `"fly"`:
+		*m = MovementFly
+	default:
+		*m = MovementNone
+	}
+	return nil
+}
+
 // Our cell-related errors.
 var (
 	ErrOutOfBoundCell = errors.New("oob cell")
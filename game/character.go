@@ -20,11 +20,16 @@ type CharacterArchetype struct {
 	Zooms  AttributeLevel // Dex, basically
 	Brains AttributeLevel // Thinkin' and spell-related
 	Funk   AttributeLevel // Charm and god-related
+	Weight int            // Weight of the body, such as when carried.
 	//Traits          []string           // Traits
 	Traits          TraitList
 	Slots           Slots              // Slots
 	StartingObjects []id.UUID          // Starting objects
 	StartingSkills  map[string]float64 // Starting skills
+	Brain           string             `msgpack:"-"` // Brain used when the archetype is a non-player character. See the server's brains for available names.
+	Drops           id.UUID            `msgpack:"-"` // Loot table dropped when the archetype is a non-player character that is slain, if any.
+	Hostile         bool               `msgpack:"-"` // If the archetype starts combat when it notices players.
+	Light           Light              // Light the character gives off, such as a faint glow.
 }
 
 // Type returns "character"
@@ -37,6 +42,16 @@ func (c CharacterArchetype) GetID() id.UUID {
 	return c.ID
 }
 
+// GetWeight returns the weight.
+func (c CharacterArchetype) GetWeight() int {
+	return c.Weight
+}
+
+// GetLight returns the light emitted.
+func (c CharacterArchetype) GetLight() Light {
+	return c.Light
+}
+
 // Character represents a character. This can be a player or an NPC.
 type Character struct {
 	Objectable
@@ -46,17 +61,21 @@ type Character struct {
 	Damager
 	Movable
 	Hungerable
-	Events     []Event    `msgpack:"-" json:"-"` // Events that have happened to the character. These are only sent to the owning client.
-	Desire     Desire     `msgpack:"-" json:"-"` // The current desire of the character. Used server-side.
-	LastDesire Desire     `msgpack:"-" json:"-"` // Last desire processed. Used server-side.
-	Name       string     `msgpack:"n,omitempty"`
-	Level      int        `msgpack:"l,omitempty"`
-	Attributes Attributes `msgpack:"t,omitempty"`
-	Slots      SlotMap    `msgpack:"-"`
-	Skills     Skills     `msgpack:"-"`
-	Inventory  Objects    `msgpack:"-"`
+	Events        []Event    `msgpack:"-" json:"-"` // Events that have happened to the character. These are only sent to the owning client.
+	Desire        Desire     `msgpack:"-" json:"-"` // The current desire of the character. Used server-side.
+	LastDesire    Desire     `msgpack:"-" json:"-"` // Last desire processed. Used server-side.
+	Name          string     `msgpack:"n,omitempty"`
+	Level         int        `msgpack:"l,omitempty"`
+	Attributes    Attributes `msgpack:"t,omitempty"`
+	Slots         SlotMap    `msgpack:"-"`
+	Skills        Skills     `msgpack:"-"`
+	SkillsChanged bool       `msgpack:"-" json:"-"` // If the skills have changed since last sent to the owning client. Used server-side.
+	Inventory     Objects    `msgpack:"-"`
+	Memory        Memory     `msgpack:"-"`          // Cells the character has seen in the locations they have visited. Used server-side.
+	MapRevealed   bool       `msgpack:"-" json:"-"` // If the whole location should be revealed to the owning client. Used server-side.
 	//
 	SpentActions int
+	Encumbrance  Encumbrance `msgpack:"e,omitempty"` // Last calculated encumbrance. See UpdateEncumbrance.
 }
 
 // Type returns "character"
@@ -89,11 +108,34 @@ func (c *Character) Apply(o Object, force bool) Event {
 	case *Food:
 		return c.applyFood(o)
 	case *Item:
-		// TODO
+		return c.applyItem(o)
 	}
 	return nil
 }
 
+// applyItem uses a charge of an item. The item's effects are left to the caller.
+func (c *Character) applyItem(i *Item) Event {
+	a, ok := i.Archetype.(ItemArchetype)
+	if !ok || !a.Usable() {
+		return EventNotice{
+			Message: lc.T("You can't use that."),
+		}
+	}
+	if !i.Use() {
+		return EventNotice{
+			Message: lc.T("Nothing happens."),
+		}
+	}
+	// Using an item reveals what it is.
+	i.Identified = true
+	return EventUse{
+		User:     c.WID,
+		WID:      i.WID,
+		Charges:  i.Charges,
+		Finished: i.Charges <= 0,
+	}
+}
+
 // applyWeapon applies a weapon to the character.
 func (c *Character) applyWeapon(w *Weapon, force bool) Event {
 	if w.Archetype != nil {
@@ -150,6 +192,7 @@ func (c *Character) applyArmor(a *Armor, force bool) Event {
 	a.Apply()
 
 	c.Hurtable.CalculateArmorFromCharacter(c)
+	c.Movable.CalculateFromCharacter(c)
 
 	return EventApply{
 		Applier: c.WID,
@@ -164,25 +207,25 @@ func (c *Character) applyFood(f *Food) Event {
 	max := c.MaxHunger + (c.MaxHunger / 3)
 
 	next := f.NextCalories()
+	// Cooking gets 10% more out of the calories per level.
+	cooking := c.Skills.Level(SkillCooking)
+	gain := next + next*cooking/10
 
-	if c.Hunger+next >= max {
-		next -= (c.Hunger + next) - max
-		if next <= 0 {
+	if c.Hunger+gain >= max {
+		gain = max - c.Hunger
+		if gain <= 0 {
 			// Cannot eat, too full.
 			return EventNotice{
 				Message: lc.T("You're too full to eat that."),
 			}
 		}
-	}
-
-	if c.Hunger+next >= c.MaxHunger {
-		fmt.Println("TODO: Show overeating")
+		// Only eat as much as fits, rounding up so at least a bite is taken.
+		next = (gain*10 + 10 + cooking - 1) / (10 + cooking)
 	}
 
 	f.CurrentCalories -= next
-	c.Hunger += next
+	c.Hunger += gain
 
-	// TODO: Apply effects of food.
 	return EventConsume{
 		Consumer:          c.WID,
 		WID:               f.WID,
@@ -248,6 +291,7 @@ func (c *Character) unapplyArmor(a *Armor, force bool) Event {
 	a.Unapply()
 
 	c.Hurtable.CalculateArmorFromCharacter(c)
+	c.Movable.CalculateFromCharacter(c)
 
 	return EventApply{
 		Applier: c.WID,
@@ -258,6 +302,12 @@ func (c *Character) unapplyArmor(a *Armor, force bool) Event {
 
 // Pickup adds an object to the character's inventory.
 func (c *Character) Pickup(o Object) Event {
+	if !c.CanCarry(o) {
+		return EventNotice{
+			Message: lc.T("You can't carry that much."),
+		}
+	}
+
 	c.Inventory = append(c.Inventory, o)
 
 	// Set container to the character.
@@ -310,22 +360,38 @@ func (c *Character) Drop(o Object) Event {
 
 // Swole returns the calculated swole of the character.
 func (c *Character) Swole() AttributeLevel {
-	return c.Attributes.Swole + c.Archetype.(CharacterArchetype).Swole
+	return c.Attributes.Swole + c.Archetype.(CharacterArchetype).Swole + c.Archetype.(CharacterArchetype).Traits.Attributes().Swole
 }
 
 // Zooms returns the calculated zooms of the character.
 func (c *Character) Zooms() AttributeLevel {
-	return c.Attributes.Zooms + c.Archetype.(CharacterArchetype).Zooms
+	return c.Attributes.Zooms + c.Archetype.(CharacterArchetype).Zooms + c.Archetype.(CharacterArchetype).Traits.Attributes().Zooms
 }
 
 // Brains returns the calculated brains of the character.
 func (c *Character) Brains() AttributeLevel {
-	return c.Attributes.Brains + c.Archetype.(CharacterArchetype).Brains
+	return c.Attributes.Brains + c.Archetype.(CharacterArchetype).Brains + c.Archetype.(CharacterArchetype).Traits.Attributes().Brains
 }
 
 // Funk returns the calculated funk of the character.
 func (c *Character) Funk() AttributeLevel {
-	return c.Attributes.Funk + c.Archetype.(CharacterArchetype).Funk
+	return c.Attributes.Funk + c.Archetype.(CharacterArchetype).Funk + c.Archetype.(CharacterArchetype).Traits.Attributes().Funk
+}
+
+// Attribute returns the calculated level of the given attribute of the character.
+func (c *Character) Attribute(a Attribute) AttributeLevel {
+	switch a {
+	case AttributeSwole:
+		return c.Swole()
+	case AttributeZooms:
+		return c.Zooms()
+	case AttributeBrains:
+		return c.Brains()
+	case AttributeFunk:
+		return c.Funk()
+	default:
+		return 0
+	}
 }
 
 // Health represents a character's health.
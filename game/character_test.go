@@ -0,0 +1,40 @@
+package game
+
+import "testing"
+
+func TestApplyFood(t *testing.T) {
+	tests := []struct {
+		name          string
+		hunger        int
+		cooking       float64
+		calories      int
+		wantHunger    int
+		wantRemaining int
+		wantFull      bool
+	}{
+		{"plain", 0, 0, 200, 200, 0, false},
+		{"cooking bonus", 0, 5, 200, 300, 0, false},
+		{"capped", 350, 0, 200, 400, 150, false},
+		{"capped with cooking bonus", 300, 5, 200, 400, 133, false},
+		{"too full", 400, 0, 200, 400, 200, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Character{Skills: Skills{SkillCooking: tt.cooking}}
+			c.Hunger = tt.hunger
+			c.MaxHunger = 300 // Overeating is allowed up to 400.
+			f := &Food{Edible: Edible{Calories: tt.calories, CurrentCalories: tt.calories}}
+
+			_, full := c.applyFood(f).(EventNotice)
+			if full != tt.wantFull {
+				t.Errorf("too full = %v, want %v", full, tt.wantFull)
+			}
+			if c.Hunger != tt.wantHunger {
+				t.Errorf("Hunger = %d, want %d", c.Hunger, tt.wantHunger)
+			}
+			if f.CurrentCalories != tt.wantRemaining {
+				t.Errorf("CurrentCalories = %d, want %d", f.CurrentCalories, tt.wantRemaining)
+			}
+		})
+	}
+}
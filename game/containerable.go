@@ -1,12 +1,16 @@
 package game
 
-import "github.com/kettek/morogue/id"
+import (
+	"errors"
+
+	"github.com/kettek/morogue/id"
+)
 
 type Containerable struct {
 	Inventory    Objects `msgpack:"o"`
-	ContainerWID id.WID  `json:"-"` // ID of the container -- this should be the same as the Objectable's.
-	Capacity     int     `msgpack:"c"`
-	Limit        int     `msgpack:"l"`
+	ContainerWID id.WID  `json:"-"`    // ID of the container -- this should be the same as the Objectable's.
+	Capacity     int     `msgpack:"c"` // Maximum number of objects that can be held. 0 means no limit.
+	Limit        int     `msgpack:"l"` // Maximum weight that can be held. 0 means no limit.
 }
 
 // InInventory returns true if the containerable has the object in its inventory.
@@ -19,6 +23,25 @@ func (c *Containerable) InInventory(wid id.WID) bool {
 	return false
 }
 
+// Weight returns the total weight of the containerable's inventory.
+func (c *Containerable) Weight() (weight int) {
+	for _, o := range c.Inventory {
+		weight += ObjectWeight(o)
+	}
+	return
+}
+
+// CanHold returns an error if the object would exceed the containerable's capacity or limit.
+func (c *Containerable) CanHold(o Object) error {
+	if c.Capacity > 0 && len(c.Inventory) >= c.Capacity {
+		return ErrContainerFull
+	}
+	if c.Limit > 0 && c.Weight()+ObjectWeight(o) > c.Limit {
+		return ErrContainerTooHeavy
+	}
+	return nil
+}
+
 // Pickup adds an object to the containerable's inventory.
 func (c *Containerable) Pickup(o Object) bool {
 	c.Inventory = append(c.Inventory, o)
@@ -51,3 +74,25 @@ func (c *Containerable) Drop(o Object) bool {
 
 	return true
 }
+
+// Weighted is implemented by archetypes that have a weight.
+type Weighted interface {
+	GetWeight() int
+}
+
+// ObjectWeight returns the weight of the object from its archetype, including the weight of anything it contains.
+func ObjectWeight(o Object) (weight int) {
+	if w, ok := o.GetArchetype().(Weighted); ok {
+		weight = w.GetWeight()
+	}
+	if b, ok := o.(*Bag); ok {
+		weight += b.Weight()
+	}
+	return
+}
+
+// Our container errors.
+var (
+	ErrContainerFull     = errors.New(lc.T("it is full"))
+	ErrContainerTooHeavy = errors.New(lc.T("it is too heavy"))
+)
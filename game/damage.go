@@ -37,7 +37,11 @@ func (d Damage) Roll() int {
 	return rand.Intn(d.Max-d.Min+1) + d.Min + d.Extra
 }
 
-// DamageResult represents the result of a damage roll.
+// DamageResult represents the result of a damage roll. Once resolved against a Hurtable, Damage is the amount that actually got through.
 type DamageResult struct {
-	Damage int
+	Source   id.WID `msgpack:"s,omitempty"` // Source of the damage, such as a weapon.
+	Damage   int    `msgpack:"d,omitempty"`
+	Absorbed int    `msgpack:"a,omitempty"` // Damage absorbed by armor.
+	Dodged   bool   `msgpack:"D,omitempty"` // Whether the damage was dodged entirely.
+	Critical bool   `msgpack:"c,omitempty"` // Whether the roll was a critical hit.
 }
@@ -1,26 +1,53 @@
 package game
 
+import (
+	"math/rand"
+
+	"github.com/kettek/morogue/id"
+)
+
 // Damager is an embed that contains logic for doing damage to objects.
 type Damager struct {
-	Damages []Damage
+	Damages    []Damage
+	CritChance float64 // Chance for a damage roll to be a critical hit, which doubles its damage.
 }
 
 // RollDamages rolls the the damager's damages.
 func (d *Damager) RollDamages() (results []DamageResult) {
 	for _, damage := range d.Damages {
-		dmg := damage.Roll()
-		results = append(results, DamageResult{
-			Damage: dmg,
-		})
+		results = append(results, d.RollDamage(damage))
 	}
 	return
 }
 
+// RollDamage rolls a single damage with the damager's crit chance.
+func (d *Damager) RollDamage(damage Damage) DamageResult {
+	dmg := damage.Roll()
+	crit := rand.Float64() < d.CritChance
+	if crit {
+		dmg *= 2
+	}
+	return DamageResult{
+		Source:   damage.Source,
+		Damage:   dmg,
+		Critical: crit,
+	}
+}
+
+// DamageFrom returns the damage for the given source, such as an applied weapon.
+func (d *Damager) DamageFrom(source id.WID) (Damage, bool) {
+	for _, damage := range d.Damages {
+		if damage.Source == source {
+			return damage, true
+		}
+	}
+	return Damage{}, false
+}
+
 // CalculateFromCharacter calculates the damages from a character.
 func (d *Damager) CalculateFromCharacter(c *Character) {
 	d.Damages = []Damage{}
 	var mainHand, offHand *Weapon
-	var mainType, offType WeaponType
 	for _, w := range c.Inventory {
 		if w, ok := w.(*Weapon); ok {
 			if !w.Applied || w.Archetype == nil {
@@ -28,61 +55,23 @@ func (d *Damager) CalculateFromCharacter(c *Character) {
 			}
 			if w.Archetype.(WeaponArchetype).Slots.HasSlot(SlotMainHand) {
 				mainHand = w
-				mainType = w.Archetype.(WeaponArchetype).WeaponType
 			} else if w.Archetype.(WeaponArchetype).Slots.HasSlot(SlotOffHand) {
 				offHand = w
-				offType = w.Archetype.(WeaponArchetype).WeaponType
 			}
 		}
 	}
-	// FIXME: Don't assume Swole, use the weapon's preferred attribute.
-	swole := c.Swole()
-	var mainMin, mainMax, mainExtra, offMin, offMax, offExtra int
 	if mainHand != nil {
-		mainMin = mainHand.Archetype.(WeaponArchetype).MinDamage
-		mainMax = mainHand.Archetype.(WeaponArchetype).MaxDamage
-		if swole > AttributeLevel(mainMin) {
-			if swole > AttributeLevel(mainMax) {
-				mainMin = mainMax
-				mainExtra = (int(swole) - mainMax) / 2
-			} else {
-				mainMin = int(swole)
-			}
-		}
-		d.Damages = append(d.Damages, Damage{
-			Source:  mainHand.WID,
-			Min:     mainMin,
-			Max:     mainMax,
-			Extra:   mainExtra,
-			Reduced: false,
-			Weapon:  mainType,
-		})
+		d.Damages = append(d.Damages, WeaponDamage(c, mainHand, false))
 	}
 	if offHand != nil {
-		offMin = offHand.Archetype.(WeaponArchetype).MinDamage
-		offMax = offHand.Archetype.(WeaponArchetype).MaxDamage
-		if swole > AttributeLevel(offMin) {
-			if swole > AttributeLevel(offMax) {
-				offMin = offMax
-				offExtra = (int(swole) - offMax) / 2
-			} else {
-				offMin = int(swole)
-			}
-		}
-		d.Damages = append(d.Damages, Damage{
-			Source:  offHand.WID,
-			Min:     offMin,
-			Max:     offMax,
-			Extra:   offExtra,
-			Reduced: true,
-			Weapon:  offType,
-		})
+		d.Damages = append(d.Damages, WeaponDamage(c, offHand, true))
 	}
 	if mainHand == nil && offHand == nil {
+		max := int(c.Swole()) / 2
 		d.Damages = append(d.Damages, Damage{
 			Source:  c.WID,
-			Min:     0,
-			Max:     int(swole) / 2,
+			Min:     proficientMin(c, WeaponTypeUnarmed, 0, max),
+			Max:     max,
 			Extra:   0,
 			Reduced: true,
 			Weapon:  WeaponTypeUnarmed,
@@ -92,4 +81,45 @@ func (d *Damager) CalculateFromCharacter(c *Character) {
 	for _, t := range c.Archetype.(CharacterArchetype).Traits {
 		d.Damages = t.AdjustDamages(d.Damages)
 	}
+
+	// 5% base crit chance, plus 1% per point of Funk.
+	d.CritChance = 0.05 + float64(c.Funk())/100
+}
+
+// WeaponDamage returns the damage the character does with the given weapon. The character's level in the weapon's primary attribute, plus 50% of its level in the secondary attribute, raises the weapon's min damage up to its max, with any excess adding half as extra damage. Proficiency in the weapon's type raises the min damage further. Weapons without a primary attribute use Swole.
+func WeaponDamage(c *Character, w *Weapon, reduced bool) Damage {
+	a := w.Archetype.(WeaponArchetype)
+	primary := a.PrimaryAttribute
+	if primary == AttributeNone {
+		primary = AttributeSwole
+	}
+	level := c.Attribute(primary) + c.Attribute(a.SecondaryAttribute)/2
+
+	min, max, extra := a.MinDamage, a.MaxDamage, 0
+	if level > AttributeLevel(min) {
+		if level > AttributeLevel(max) {
+			min = max
+			extra = (int(level) - max) / 2
+		} else {
+			min = int(level)
+		}
+	}
+	min = proficientMin(c, a.WeaponType, min, max)
+	return Damage{
+		Source:  w.WID,
+		Min:     min,
+		Max:     max,
+		Extra:   extra,
+		Reduced: reduced,
+		Weapon:  a.WeaponType,
+	}
+}
+
+// proficientMin raises the min damage by the character's proficiency with the weapon type, up to max.
+func proficientMin(c *Character, w WeaponType, min, max int) int {
+	min += c.Skills.Level(WeaponSkill(w))
+	if min > max {
+		return max
+	}
+	return min
 }
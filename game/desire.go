@@ -35,6 +35,14 @@ func (w *DesireWrapper) Desire() Desire {
 		var d DesireDrop
 		msgpack.Unmarshal(w.Data, &d)
 		return d
+	case (DesireStash{}).Type():
+		var d DesireStash
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (DesireUnstash{}).Type():
+		var d DesireUnstash
+		msgpack.Unmarshal(w.Data, &d)
+		return d
 	case (DesireBash{}).Type():
 		var d DesireBash
 		msgpack.Unmarshal(w.Data, &d)
@@ -43,10 +51,38 @@ func (w *DesireWrapper) Desire() Desire {
 		var d DesireOpen
 		msgpack.Unmarshal(w.Data, &d)
 		return d
+	case (DesireLock{}).Type():
+		var d DesireLock
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (DesireUnlock{}).Type():
+		var d DesireUnlock
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (DesireSearch{}).Type():
+		var d DesireSearch
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (DesireDisarm{}).Type():
+		var d DesireDisarm
+		msgpack.Unmarshal(w.Data, &d)
+		return d
 	case (DesirePing{}).Type():
 		var d DesirePing
 		msgpack.Unmarshal(w.Data, &d)
 		return d
+	case (DesireTravel{}).Type():
+		var d DesireTravel
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (DesireShoot{}).Type():
+		var d DesireShoot
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (DesireThrow{}).Type():
+		var d DesireThrow
+		msgpack.Unmarshal(w.Data, &d)
+		return d
 	}
 	return nil
 }
@@ -92,6 +128,27 @@ func (d DesireDrop) Type() string {
 	return "drop"
 }
 
+// DesireStash represents the desire to put an object from the inventory into a bag.
+type DesireStash struct {
+	WID id.WID `msgpack:"wid,omitempty"`
+	Bag id.WID `msgpack:"b,omitempty"`
+}
+
+// Type returns "stash".
+func (d DesireStash) Type() string {
+	return "stash"
+}
+
+// DesireUnstash represents the desire to take an object out of a bag and into the inventory.
+type DesireUnstash struct {
+	WID id.WID `msgpack:"wid,omitempty"`
+}
+
+// Type returns "unstash".
+func (d DesireUnstash) Type() string {
+	return "unstash"
+}
+
 // DesireBash represents the desire to bash a particular object or direction.
 type DesireBash struct {
 	WID       id.WID        `msgpack:"wid,omitempty"`
@@ -114,6 +171,45 @@ func (d DesireOpen) Type() string {
 	return "open"
 }
 
+// DesireLock represents the desire to lock a particular object with a key. If WID is 0, the first unlocked object within reach is used.
+type DesireLock struct {
+	WID id.WID `msgpack:"wid,omitempty"`
+}
+
+// Type returns "lock".
+func (d DesireLock) Type() string {
+	return "lock"
+}
+
+// DesireUnlock represents the desire to unlock a particular object, either with a key or by picking it. If WID is 0, the first locked object within reach is used.
+type DesireUnlock struct {
+	WID id.WID `msgpack:"wid,omitempty"`
+}
+
+// Type returns "unlock".
+func (d DesireUnlock) Type() string {
+	return "unlock"
+}
+
+// DesireSearch represents the desire to search the surrounding cells for hidden traps.
+type DesireSearch struct {
+}
+
+// Type returns "search".
+func (d DesireSearch) Type() string {
+	return "search"
+}
+
+// DesireDisarm represents the desire to disarm a particular trap. If WID is 0, the first revealed trap within reach is used.
+type DesireDisarm struct {
+	WID id.WID `msgpack:"wid,omitempty"`
+}
+
+// Type returns "disarm".
+func (d DesireDisarm) Type() string {
+	return "disarm"
+}
+
 // DesirePing represents the desire to ping a location or WID to other players.
 type DesirePing struct {
 	WID      id.WID   `msgpack:"wid,omitempty"`
@@ -125,3 +221,35 @@ type DesirePing struct {
 func (d DesirePing) Type() string {
 	return "ping"
 }
+
+// DesireTravel represents the desire to use stairs or a portal. If WID is 0, the stairs beneath the character are used.
+type DesireTravel struct {
+	WID id.WID `msgpack:"wid,omitempty"`
+}
+
+// Type returns "travel".
+func (d DesireTravel) Type() string {
+	return "travel"
+}
+
+// DesireShoot represents the desire to shoot an applied ranged weapon at a position.
+type DesireShoot struct {
+	WID      id.WID   `msgpack:"wid,omitempty"` // The weapon to shoot with. If 0, the first applied ranged weapon is used.
+	Position Position `msgpack:"p,omitempty"`
+}
+
+// Type returns "shoot".
+func (d DesireShoot) Type() string {
+	return "shoot"
+}
+
+// DesireThrow represents the desire to throw an object from the inventory at a position.
+type DesireThrow struct {
+	WID      id.WID   `msgpack:"wid,omitempty"`
+	Position Position `msgpack:"p,omitempty"`
+}
+
+// Type returns "throw".
+func (d DesireThrow) Type() string {
+	return "throw"
+}
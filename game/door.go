@@ -12,6 +12,8 @@ type DoorArchetype struct {
 	BlockType BlockType `msgpack:"-"`
 	Health    int       `msgpack:"-"`
 	MaxHealth int       `msgpack:"-"`
+	Weight    int       `msgpack:"W,omitempty"` // Weight when carried.
+	Debris    id.UUID   `msgpack:"-"`           // Archetype left behind when broken, if any.
 }
 
 // Type returns the type of this archetype.
@@ -24,6 +26,11 @@ func (d DoorArchetype) GetID() id.UUID {
 	return d.ID
 }
 
+// GetWeight returns the weight.
+func (d DoorArchetype) GetWeight() int {
+	return d.Weight
+}
+
 // Door is a door.
 type Door struct {
 	Objectable
@@ -35,6 +42,21 @@ type Door struct {
 }
 
 // Type returns the type of this object.
-func (o Door) Type() ObjectType {
+func (o *Door) Type() ObjectType {
 	return "door"
 }
+
+// Blocks returns true if the door is closed and blocks movement.
+func (o *Door) Blocks() bool {
+	return !o.Opened && o.IsBlocked()
+}
+
+// CanBreak returns true if the door has health to lose.
+func (o *Door) CanBreak() bool {
+	return o.MaxHealth > 0
+}
+
+// IsBroken returns true if the door has been bashed out of health.
+func (o *Door) IsBroken() bool {
+	return o.CanBreak() && o.Health <= 0
+}
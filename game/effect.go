@@ -0,0 +1,36 @@
+package game
+
+import "github.com/kettek/morogue/id"
+
+// Our effect kinds.
+const (
+	EffectHeal      = "heal"       // Heals Amount health.
+	EffectCure      = "cure"       // Removes the named status, or all harmful statuses if no name is given.
+	EffectTeleport  = "teleport"   // Moves the user to a random open cell in the location.
+	EffectRevealMap = "reveal-map" // Reveals every cell of the location to the user.
+	EffectStatus    = "status"     // Grants Status to the user.
+	EffectSpawn     = "spawn"      // Spawns Count of the Object archetype around the user.
+	EffectIdentify  = "identify"   // Identifies every item the user carries.
+)
+
+// Effect is a single effect of using an item, such as drinking a potion or reading a scroll.
+type Effect struct {
+	Kind   string  `msgpack:"k"`
+	Amount int     `msgpack:"a,omitempty"` // Amount healed.
+	Name   string  `msgpack:"n,omitempty"` // Name of the status to cure.
+	Status Status  `msgpack:"s,omitempty"` // Status granted.
+	Object id.UUID `msgpack:"o,omitempty"` // Archetype of the object spawned.
+	Count  int     `msgpack:"c,omitempty"` // How many objects to spawn. 0 means 1.
+}
+
+// Effects is a list of effects.
+type Effects []Effect
+
+// HarmfulStatus returns true if the named status is one cured by a cure effect that names no status.
+func HarmfulStatus(name string) bool {
+	switch name {
+	case StatusPoison, StatusSlow:
+		return true
+	}
+	return false
+}
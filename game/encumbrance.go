@@ -0,0 +1,113 @@
+package game
+
+import "image/color"
+
+// Encumbrance is how burdened a character is by the weight they carry.
+type Encumbrance uint8
+
+// Our encumbrance tiers.
+const (
+	EncumbranceNone     Encumbrance = iota // At or under carry capacity.
+	EncumbranceBurdened                    // Over carry capacity. Slows the character and uses more energy.
+	EncumbranceStressed                    // Over 1.5x carry capacity. Slows the character further and uses even more energy.
+)
+
+// String returns the string representation of the encumbrance.
+func (e Encumbrance) String() string {
+	switch e {
+	case EncumbranceBurdened:
+		return lc.T("burdened")
+	case EncumbranceStressed:
+		return lc.T("stressed")
+	default:
+		return ""
+	}
+}
+
+// Color returns the color associated with the encumbrance.
+func (e Encumbrance) Color() color.Color {
+	switch e {
+	case EncumbranceBurdened:
+		return color.NRGBA{R: 250, G: 200, B: 50, A: 255}
+	case EncumbranceStressed:
+		return color.NRGBA{R: 250, G: 100, B: 50, A: 255}
+	default:
+		return color.NRGBA{R: 200, G: 200, B: 200, A: 255}
+	}
+}
+
+// ActionPenalty returns the actions per turn lost to the encumbrance.
+func (e Encumbrance) ActionPenalty() int {
+	switch e {
+	case EncumbranceBurdened:
+		return 1
+	case EncumbranceStressed:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// AdjustEnergy adjusts energy use for the encumbrance.
+func (e Encumbrance) AdjustEnergy(energy int) int {
+	switch e {
+	case EncumbranceBurdened:
+		return energy + energy/2
+	case EncumbranceStressed:
+		return energy * 2
+	default:
+		return energy
+	}
+}
+
+// Load returns the total weight of the character's inventory.
+func (c *Character) Load() (load int) {
+	for _, o := range c.Inventory {
+		load += ObjectWeight(o)
+	}
+	return
+}
+
+// CarryCapacity returns the weight the character can carry before becoming encumbered.
+func (c *Character) CarryCapacity() int {
+	return 1000 + int(c.Swole()*250)
+}
+
+// MaxLoad returns the most weight the character can carry at all.
+func (c *Character) MaxLoad() int {
+	return c.CarryCapacity() * 2
+}
+
+// CanCarry returns true if the character can carry the object on top of their current load. Characters without an archetype have nothing to go by, so they can carry anything.
+func (c *Character) CanCarry(o Object) bool {
+	if _, ok := c.Archetype.(CharacterArchetype); !ok {
+		return true
+	}
+	return c.Load()+ObjectWeight(o) <= c.MaxLoad()
+}
+
+// CurrentEncumbrance returns the encumbrance for the character's current load.
+func (c *Character) CurrentEncumbrance() Encumbrance {
+	if _, ok := c.Archetype.(CharacterArchetype); !ok {
+		return EncumbranceNone
+	}
+	load, capacity := c.Load(), c.CarryCapacity()
+	switch {
+	case load > capacity+capacity/2:
+		return EncumbranceStressed
+	case load > capacity:
+		return EncumbranceBurdened
+	default:
+		return EncumbranceNone
+	}
+}
+
+// UpdateEncumbrance updates the encumbrance and returns true if it changed.
+func (c *Character) UpdateEncumbrance() bool {
+	e := c.CurrentEncumbrance()
+	if e == c.Encumbrance {
+		return false
+	}
+	c.Encumbrance = e
+	return true
+}
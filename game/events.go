@@ -56,6 +56,14 @@ func (w *EventWrapper) Event() Event {
 		var d EventDrop
 		msgpack.Unmarshal(w.Data, &d)
 		return d
+	case (EventStash{}).Type():
+		var d EventStash
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (EventUnstash{}).Type():
+		var d EventUnstash
+		msgpack.Unmarshal(w.Data, &d)
+		return d
 	case (EventApply{}).Type():
 		var d EventApply
 		msgpack.Unmarshal(w.Data, &d)
@@ -68,6 +76,34 @@ func (w *EventWrapper) Event() Event {
 		var d EventHunger
 		msgpack.Unmarshal(w.Data, &d)
 		return d
+	case (EventHungerState{}).Type():
+		var d EventHungerState
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (EventEncumbrance{}).Type():
+		var d EventEncumbrance
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (EventBlocks{}).Type():
+		var d EventBlocks
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (EventLock{}).Type():
+		var d EventLock
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (EventUse{}).Type():
+		var d EventUse
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (EventIdentify{}).Type():
+		var d EventIdentify
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (EventTrap{}).Type():
+		var d EventTrap
+		msgpack.Unmarshal(w.Data, &d)
+		return d
 	case (EventNotice{}).Type():
 		var d EventNotice
 		msgpack.Unmarshal(w.Data, &d)
@@ -84,6 +120,30 @@ func (w *EventWrapper) Event() Event {
 		var d EventTurn
 		msgpack.Unmarshal(w.Data, &d)
 		return d
+	case (EventProjectile{}).Type():
+		var d EventProjectile
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (EventMode{}).Type():
+		var d EventMode
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (EventLevelUp{}).Type():
+		var d EventLevelUp
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (EventStatusAdd{}).Type():
+		var d EventStatusAdd
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (EventStatusTick{}).Type():
+		var d EventStatusTick
+		msgpack.Unmarshal(w.Data, &d)
+		return d
+	case (EventStatusExpire{}).Type():
+		var d EventStatusExpire
+		msgpack.Unmarshal(w.Data, &d)
+		return d
 	case (EventPing{}).Type():
 		var d EventPing
 		msgpack.Unmarshal(w.Data, &d)
@@ -216,6 +276,28 @@ func (e EventHunger) Type() string {
 	return "hunger"
 }
 
+// EventHungerState notifies the client that a character's hunger has crossed into a new state.
+type EventHungerState struct {
+	WID   id.WID      `msgpack:"w,omitempty"`
+	State HungerState `msgpack:"s,omitempty"`
+}
+
+// Type returns "hunger-state"
+func (e EventHungerState) Type() string {
+	return "hunger-state"
+}
+
+// EventEncumbrance notifies the client that a character's load has crossed into a new encumbrance.
+type EventEncumbrance struct {
+	WID         id.WID      `msgpack:"w,omitempty"`
+	Encumbrance Encumbrance `msgpack:"e,omitempty"`
+}
+
+// Type returns "encumbrance"
+func (e EventEncumbrance) Type() string {
+	return "encumbrance"
+}
+
 // EventPickup notifies the client that the given item was picked up.
 type EventPickup struct {
 	Picker id.WID `msgpack:"p,omitempty"`
@@ -283,6 +365,90 @@ func (e *EventDrop) UnmarshalMsgpack(b []byte) error {
 	return nil
 }
 
+// EventStash notifies the client that the given item was put into a bag.
+type EventStash struct {
+	Stasher id.WID `msgpack:"s,omitempty"`
+	WID     id.WID
+	Bag     id.WID `msgpack:"b,omitempty"`
+}
+
+// Type returns "stash"
+func (e EventStash) Type() string {
+	return "stash"
+}
+
+// EventUnstash notifies the client that the given item was taken out of a bag.
+type EventUnstash struct {
+	Unstasher id.WID `msgpack:"u,omitempty"`
+	WID       id.WID
+	Bag       id.WID `msgpack:"b,omitempty"`
+}
+
+// Type returns "unstash"
+func (e EventUnstash) Type() string {
+	return "unstash"
+}
+
+// EventBlocks notifies the client that the blocking of the cell at the given position changed, such as from a door opening or breaking.
+type EventBlocks struct {
+	Position `msgpack:"p,omitempty"`
+	Blocks   MovementType `msgpack:"b,omitempty"`
+	Opaque   bool         `msgpack:"o,omitempty"`
+}
+
+// Type returns "blocks"
+func (e EventBlocks) Type() string {
+	return "blocks"
+}
+
+// EventLock notifies the client that the given object was locked or unlocked.
+type EventLock struct {
+	Locker id.WID `msgpack:"l,omitempty"`
+	WID    id.WID
+	Locked bool `msgpack:"L,omitempty"`
+}
+
+// Type returns "lock"
+func (e EventLock) Type() string {
+	return "lock"
+}
+
+// EventUse notifies the client that the given item was used, along with its remaining charges.
+type EventUse struct {
+	User     id.WID `msgpack:"u,omitempty"`
+	WID      id.WID `msgpack:"w,omitempty"`
+	Charges  int    `msgpack:"c,omitempty"`
+	Finished bool   `msgpack:"f,omitempty"` // The item is used up.
+}
+
+// Type returns "use"
+func (e EventUse) Type() string {
+	return "use"
+}
+
+// EventIdentify notifies the client that the given item has been identified.
+type EventIdentify struct {
+	WID id.WID `msgpack:"w,omitempty"`
+}
+
+// Type returns "identify"
+func (e EventIdentify) Type() string {
+	return "identify"
+}
+
+// EventTrap notifies the client that the given trap was revealed, set off, or disarmed. Trigger is whoever set it off, if anyone.
+type EventTrap struct {
+	Trigger  id.WID `msgpack:"t,omitempty"`
+	WID      id.WID
+	Revealed bool `msgpack:"r,omitempty"`
+	Disarmed bool `msgpack:"D,omitempty"`
+}
+
+// Type returns "trap"
+func (e EventTrap) Type() string {
+	return "trap"
+}
+
 // EventNotice notifies the client of a generic notice.
 type EventNotice struct {
 	Message string
@@ -329,6 +495,77 @@ func (e EventTurn) Type() string {
 	return "turn"
 }
 
+// EventProjectile notifies the client that something was shot or thrown from one position to another.
+type EventProjectile struct {
+	From         id.WID     `msgpack:"f,omitempty"`   // The shooter or thrower.
+	WID          id.WID     `msgpack:"wid,omitempty"` // The object thrown or the weapon shot from.
+	Weapon       WeaponType `msgpack:"w,omitempty"`
+	FromPosition Position   `msgpack:"fp,omitempty"`
+	Position     Position   `msgpack:"p,omitempty"`
+}
+
+// Type returns "projectile"
+func (e EventProjectile) Type() string {
+	return "projectile"
+}
+
+// EventMode notifies the client that a location has switched between real-time and turn-based processing.
+type EventMode struct {
+	InTurns bool `msgpack:"i,omitempty"`
+	Turn    int  `msgpack:"t,omitempty"`
+}
+
+// Type returns "mode"
+func (e EventMode) Type() string {
+	return "mode"
+}
+
+// EventLevelUp notifies the client that a character's attribute has crossed a whole level.
+type EventLevelUp struct {
+	WID       id.WID         `msgpack:"wid,omitempty"`
+	Attribute Attribute      `msgpack:"a,omitempty"`
+	Value     AttributeLevel `msgpack:"v,omitempty"` // The character's new value for the attribute, not including its archetype.
+	Level     int            `msgpack:"l,omitempty"` // The character's new overall level.
+}
+
+// Type returns "level-up"
+func (e EventLevelUp) Type() string {
+	return "level-up"
+}
+
+// EventStatusAdd notifies the client that a status was added to or stacked on a target. Status is the resulting status.
+type EventStatusAdd struct {
+	Target id.WID `msgpack:"t,omitempty"`
+	Status Status `msgpack:"s,omitempty"`
+}
+
+// Type returns "status-add"
+func (e EventStatusAdd) Type() string {
+	return "status-add"
+}
+
+// EventStatusTick notifies the client that a target's status has ticked for a turn. Status is the status with its remaining duration.
+type EventStatusTick struct {
+	Target id.WID `msgpack:"t,omitempty"`
+	Status Status `msgpack:"s,omitempty"`
+}
+
+// Type returns "status-tick"
+func (e EventStatusTick) Type() string {
+	return "status-tick"
+}
+
+// EventStatusExpire notifies the client that a target's status has run out.
+type EventStatusExpire struct {
+	Target id.WID `msgpack:"t,omitempty"`
+	Name   string `msgpack:"n,omitempty"`
+}
+
+// Type returns "status-expire"
+func (e EventStatusExpire) Type() string {
+	return "status-expire"
+}
+
 // EventPing notifies a client that a player has pinged a location.
 type EventPing struct {
 	From     id.WID   `msgpack:"f,omitempty"`
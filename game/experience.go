@@ -0,0 +1,53 @@
+package game
+
+import "math"
+
+// Our experience amounts granted for using attributes. These are reduced by the attribute's current level, so higher levels take longer to reach.
+const (
+	ExperienceHit      AttributeLevel = 0.1  // Hitting with a weapon, granted to its primary attribute and half to its secondary.
+	ExperienceCritical AttributeLevel = 0.1  // Landing a critical hit, granted to Funk.
+	ExperienceDodge    AttributeLevel = 0.1  // Dodging a hit, granted to Zooms.
+	ExperienceMove     AttributeLevel = 0.01 // Moving a cell, granted to Zooms.
+	ExperienceOpen     AttributeLevel = 0.02 // Opening something, granted to Brains.
+	ExperienceTrap     AttributeLevel = 0.05 // Finding or disarming a trap, granted to Brains.
+)
+
+// GainExperience raises the fractional part of the character's attribute by the given experience. If this crosses a whole number, the character's level is increased, its derived values are recalculated, and an EventLevelUp is returned. Otherwise nil is returned.
+func (c *Character) GainExperience(a Attribute, amount AttributeLevel) Event {
+	var attr *AttributeLevel
+	switch a {
+	case AttributeSwole:
+		attr = &c.Attributes.Swole
+	case AttributeZooms:
+		attr = &c.Attributes.Zooms
+	case AttributeBrains:
+		attr = &c.Attributes.Brains
+	case AttributeFunk:
+		attr = &c.Attributes.Funk
+	default:
+		return nil
+	}
+
+	before := math.Floor(float64(*attr))
+	*attr += amount / (1 + AttributeLevel(math.Floor(float64(c.Attribute(a)))))
+	if math.Floor(float64(*attr)) <= before {
+		return nil
+	}
+
+	c.Level++
+	c.Hurtable.CalculateFromCharacter(c)
+	c.Hurtable.CalculateArmorFromCharacter(c)
+	c.Damager.CalculateFromCharacter(c)
+	c.Movable.CalculateFromCharacter(c)
+	// Only rescale hunger for those that get hungry, as mobs have no max hunger to scale from.
+	if c.MaxHunger > 0 {
+		c.Hungerable.CalculateFromCharacter(c)
+	}
+
+	return EventLevelUp{
+		WID:       c.WID,
+		Attribute: a,
+		Value:     *attr,
+		Level:     c.Level,
+	}
+}
@@ -0,0 +1,32 @@
+package game
+
+import "testing"
+
+func TestGainExperienceHunger(t *testing.T) {
+	tests := []struct {
+		name          string
+		hunger        int
+		maxHunger     int
+		wantMaxHunger int
+	}{
+		{"never hungry", 0, 0, 0},
+		{"hungry", 500, 1000, 2000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Character{}
+			c.Archetype = CharacterArchetype{}
+			c.Hunger = tt.hunger
+			c.MaxHunger = tt.maxHunger
+			if e := c.GainExperience(AttributeSwole, 1); e == nil {
+				t.Fatal("GainExperience() did not level up")
+			}
+			if c.MaxHunger != tt.wantMaxHunger {
+				t.Errorf("MaxHunger = %d, want %d", c.MaxHunger, tt.wantMaxHunger)
+			}
+			if c.Hunger != tt.wantMaxHunger/2 {
+				t.Errorf("Hunger = %d, want %d", c.Hunger, tt.wantMaxHunger/2)
+			}
+		})
+	}
+}
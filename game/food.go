@@ -5,10 +5,12 @@ import "github.com/kettek/morogue/id"
 // FoodArchetype is effectively a blueprint for food.
 type FoodArchetype struct {
 	ID          id.UUID
-	Title       string `msgpack:"T,omitempty"`
-	Description string `msgpack:"d,omitempty"`
-	Image       string `msgpack:"i,omitempty"`
-	Calories    int    `msgpack:"c,omitempty"`
+	Title       string   `msgpack:"T,omitempty"`
+	Description string   `msgpack:"d,omitempty"`
+	Image       string   `msgpack:"i,omitempty"`
+	Calories    int      `msgpack:"c,omitempty"`
+	Effects     Statuses `msgpack:"e,omitempty"` // Statuses inflicted on whoever finishes eating the food.
+	Weight      int      `msgpack:"W,omitempty"` // Weight when carried.
 }
 
 // Type returns "food".
@@ -21,6 +23,11 @@ func (a FoodArchetype) GetID() id.UUID {
 	return a.ID
 }
 
+// GetWeight returns the weight.
+func (a FoodArchetype) GetWeight() int {
+	return a.Weight
+}
+
 // Food represents a food object in the world.
 type Food struct {
 	Objectable
@@ -0,0 +1,88 @@
+package game
+
+// SightRadius is how far characters can see.
+const SightRadius = 12
+
+// Visibility is a 2D array of which cells are visible.
+type Visibility [][]bool
+
+// Visible returns true if the given position is visible.
+func (v Visibility) Visible(p Position) bool {
+	if p.X < 0 || p.Y < 0 || p.X >= len(v) || p.Y >= len(v[p.X]) {
+		return false
+	}
+	return v[p.X][p.Y]
+}
+
+// octants are the multipliers used to transform the first octant into the other seven.
+var octants = [8][4]int{
+	{1, 0, 0, 1},
+	{0, 1, 1, 0},
+	{0, -1, 1, 0},
+	{-1, 0, 0, 1},
+	{-1, 0, 0, -1},
+	{0, -1, -1, 0},
+	{0, 1, -1, 0},
+	{1, 0, 0, -1},
+}
+
+// FOV returns the cells visible from the origin within the given radius using recursive shadowcasting. Opaque cells are themselves visible, but hide the cells behind them.
+func (c Cells) FOV(origin Position, radius int) Visibility {
+	v := make(Visibility, len(c))
+	for x := range c {
+		v[x] = make([]bool, len(c[x]))
+	}
+	if _, err := c.At(origin.X, origin.Y); err != nil {
+		return v
+	}
+	v[origin.X][origin.Y] = true
+	for _, o := range octants {
+		c.castLight(v, origin, radius, 1, 1.0, 0.0, o[0], o[1], o[2], o[3])
+	}
+	return v
+}
+
+// castLight scans a single octant row by row, recursing whenever an opaque cell splits the visible slopes.
+func (c Cells) castLight(v Visibility, origin Position, radius, row int, start, end float64, xx, xy, yx, yy int) {
+	if start < end {
+		return
+	}
+	radius2 := radius * radius
+	newStart := 0.0
+	for j := row; j <= radius; j++ {
+		dx, dy := -j-1, -j
+		blocked := false
+		for dx <= 0 {
+			dx++
+			x := origin.X + dx*xx + dy*xy
+			y := origin.Y + dx*yx + dy*yy
+			lSlope := (float64(dx) - 0.5) / (float64(dy) + 0.5)
+			rSlope := (float64(dx) + 0.5) / (float64(dy) - 0.5)
+			if start < rSlope {
+				continue
+			} else if end > lSlope {
+				break
+			}
+			cell, err := c.At(x, y)
+			if err == nil && dx*dx+dy*dy <= radius2 {
+				v[x][y] = true
+			}
+			opaque := err != nil || cell.Opaque
+			if blocked {
+				if opaque {
+					newStart = rSlope
+					continue
+				}
+				blocked = false
+				start = newStart
+			} else if opaque && j < radius {
+				blocked = true
+				c.castLight(v, origin, radius, j+1, start, lSlope, xx, xy, yx, yy)
+				newStart = rSlope
+			}
+		}
+		if blocked {
+			break
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package game
+
+import "testing"
+
+// cellsFromRows builds cells from rows of text, where '#' is an opaque cell and '@' is returned as the origin.
+func cellsFromRows(rows []string) (cells Cells, origin Position) {
+	cells = NewCells(len(rows[0]), len(rows))
+	for y, row := range rows {
+		for x, r := range row {
+			switch r {
+			case '#':
+				cells[x][y].Opaque = true
+			case '@':
+				origin = Position{X: x, Y: y}
+			}
+		}
+	}
+	return
+}
+
+func TestFOV(t *testing.T) {
+	room := []string{
+		".........",
+		".........",
+		".........",
+		".........",
+		"....@.#..",
+		".........",
+		".........",
+		".........",
+		".........",
+	}
+	tests := []struct {
+		name   string
+		rows   []string
+		radius int
+		at     Position
+		want   bool
+	}{
+		{"origin", room, 4, Position{X: 4, Y: 4}, true},
+		{"adjacent", room, 4, Position{X: 5, Y: 4}, true},
+		{"opaque cell itself", room, 4, Position{X: 6, Y: 4}, true},
+		{"behind opaque cell", room, 4, Position{X: 7, Y: 4}, false},
+		{"far behind opaque cell", room, 4, Position{X: 8, Y: 4}, false},
+		{"diagonal", room, 4, Position{X: 6, Y: 6}, true},
+		{"opposite side", room, 4, Position{X: 0, Y: 4}, true},
+		{"at radius", room, 2, Position{X: 4, Y: 6}, true},
+		{"beyond radius", room, 2, Position{X: 4, Y: 7}, false},
+		{"out of bounds", room, 4, Position{X: 9, Y: 4}, false},
+		{"walled in", []string{
+			"#####",
+			"#...#",
+			"#.@.#",
+			"#...#",
+			"#####",
+			".....",
+		}, 4, Position{X: 2, Y: 5}, false},
+		{"around a corner", []string{
+			"@....",
+			"####.",
+			".....",
+		}, 4, Position{X: 0, Y: 2}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cells, origin := cellsFromRows(tt.rows)
+			if got := cells.FOV(origin, tt.radius).Visible(tt.at); got != tt.want {
+				t.Errorf("Visible(%v) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFOVOutOfBoundsOrigin(t *testing.T) {
+	cells := Cells(NewCells(3, 3))
+	v := cells.FOV(Position{X: -1, Y: 1}, 4)
+	for x := range v {
+		for y := range v[x] {
+			if v[x][y] {
+				t.Errorf("cell %d,%d visible from outside of the cells", x, y)
+			}
+		}
+	}
+}
@@ -1,9 +1,83 @@
 package game
 
+import "image/color"
+
+// HungerState is how hungry a character is.
+type HungerState uint8
+
+// Our hunger states.
+const (
+	HungerStateFed      HungerState = iota // Neither full nor hungry.
+	HungerStateSatiated                    // Overeaten. Slows the character.
+	HungerStateHungry                      // 25% or less. Halves regen.
+	HungerStateWeak                        // 10% or less. No regen and slows the character.
+	HungerStateFainting                    // 3% or less. No regen, slows the character, and may cause fainting.
+	HungerStateStarving                    // Empty. Starvation damage on top of fainting.
+)
+
+// String returns the string representation of the hunger state.
+func (s HungerState) String() string {
+	switch s {
+	case HungerStateSatiated:
+		return lc.T("satiated")
+	case HungerStateHungry:
+		return lc.T("hungry")
+	case HungerStateWeak:
+		return lc.T("weak")
+	case HungerStateFainting:
+		return lc.T("fainting")
+	case HungerStateStarving:
+		return lc.T("starving")
+	default:
+		return ""
+	}
+}
+
+// Color returns the color associated with the hunger state.
+func (s HungerState) Color() color.Color {
+	switch s {
+	case HungerStateSatiated:
+		return color.NRGBA{R: 150, G: 200, B: 50, A: 255}
+	case HungerStateHungry:
+		return color.NRGBA{R: 250, G: 200, B: 50, A: 255}
+	case HungerStateWeak:
+		return color.NRGBA{R: 250, G: 150, B: 50, A: 255}
+	case HungerStateFainting:
+		return color.NRGBA{R: 250, G: 100, B: 50, A: 255}
+	case HungerStateStarving:
+		return color.NRGBA{R: 250, G: 32, B: 32, A: 255}
+	default:
+		return color.NRGBA{R: 255, G: 255, B: 32, A: 255}
+	}
+}
+
+// ActionPenalty returns the actions per turn lost to the hunger state.
+func (s HungerState) ActionPenalty() int {
+	switch s {
+	case HungerStateSatiated, HungerStateWeak, HungerStateFainting, HungerStateStarving:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AdjustRegen adjusts health regen for the hunger state.
+func (s HungerState) AdjustRegen(regen int) int {
+	switch s {
+	case HungerStateHungry:
+		return regen / 2
+	case HungerStateWeak, HungerStateFainting, HungerStateStarving:
+		return 0
+	default:
+		return regen
+	}
+}
+
 // Hungerable is an embed that provides logic for being hungry.
 type Hungerable struct {
-	Hunger    int `msgpack:"h,omitempty"`
-	MaxHunger int `msgpack:"H,omitempty"`
+	Hunger      int         `msgpack:"h,omitempty"`
+	MaxHunger   int         `msgpack:"H,omitempty"`
+	HungerState HungerState `msgpack:"s,omitempty"` // Last calculated hunger state. See UpdateHungerState.
 }
 
 // CalculateFromCharacter calculates the hunger from a character.
@@ -27,3 +101,33 @@ func (h *Hungerable) UseEnergy(amount int) bool {
 	}
 	return h.Hunger != 0
 }
+
+// CurrentHungerState returns the hunger state for the current hunger. Anything without a max hunger is always fed.
+func (h *Hungerable) CurrentHungerState() HungerState {
+	switch {
+	case h.MaxHunger <= 0: // Not something that gets hungry.
+		return HungerStateFed
+	case h.Hunger > h.MaxHunger:
+		return HungerStateSatiated
+	case h.Hunger <= 0:
+		return HungerStateStarving
+	case h.Hunger <= h.MaxHunger*3/100:
+		return HungerStateFainting
+	case h.Hunger <= h.MaxHunger/10:
+		return HungerStateWeak
+	case h.Hunger <= h.MaxHunger/4:
+		return HungerStateHungry
+	default:
+		return HungerStateFed
+	}
+}
+
+// UpdateHungerState updates the hunger state and returns true if it changed.
+func (h *Hungerable) UpdateHungerState() bool {
+	state := h.CurrentHungerState()
+	if state == h.HungerState {
+		return false
+	}
+	h.HungerState = state
+	return true
+}
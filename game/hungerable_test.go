@@ -0,0 +1,43 @@
+package game
+
+import "testing"
+
+func TestCurrentHungerState(t *testing.T) {
+	tests := []struct {
+		name              string
+		hunger, maxHunger int
+		want              HungerState
+	}{
+		{"no max hunger", 0, 0, HungerStateFed},
+		{"full", 1000, 1000, HungerStateFed},
+		{"overeaten", 1001, 1000, HungerStateSatiated},
+		{"just fed", 251, 1000, HungerStateFed},
+		{"hungry", 250, 1000, HungerStateHungry},
+		{"weak", 100, 1000, HungerStateWeak},
+		{"fainting", 30, 1000, HungerStateFainting},
+		{"almost starving", 1, 1000, HungerStateFainting},
+		{"starving", 0, 1000, HungerStateStarving},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Hungerable{Hunger: tt.hunger, MaxHunger: tt.maxHunger}
+			if got := h.CurrentHungerState(); got != tt.want {
+				t.Errorf("CurrentHungerState() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateHungerState(t *testing.T) {
+	h := Hungerable{Hunger: 500, MaxHunger: 1000}
+	if h.UpdateHungerState() {
+		t.Error("UpdateHungerState() changed while fed")
+	}
+	h.UseEnergy(300)
+	if !h.UpdateHungerState() || h.HungerState != HungerStateHungry {
+		t.Errorf("HungerState = %v, want %v", h.HungerState, HungerStateHungry)
+	}
+	if h.UseEnergy(500) || h.Hunger != 0 {
+		t.Errorf("Hunger = %d after using more energy than left, want 0", h.Hunger)
+	}
+}
@@ -2,17 +2,20 @@ package game
 
 import (
 	"fmt"
+	"math/rand"
 )
 
-// Hurtable is an embed that provides logic for being hurt. This includes health, regen, downs, and armor.
+// Hurtable is an embed that provides logic for being hurt. This includes health, regen, downs, armor, and statuses.
 type Hurtable struct {
-	Health      int `msgpack:"h,omitempty"`
-	MaxHealth   int `msgpack:"H,omitempty"`
-	HealthRegen int `msgpack:"r,omitempty"`
-	Downs       int `msgpack:"d,omitempty"`
-	MaxDowns    int `msgpack:"D,omitempty"`
-	MinArmor    int `msgpack:"a,omitempty"`
-	MaxArmor    int `msgpack:"A,omitempty"`
+	Health      int      `msgpack:"h,omitempty"`
+	MaxHealth   int      `msgpack:"H,omitempty"`
+	HealthRegen int      `msgpack:"r,omitempty"`
+	Downs       int      `msgpack:"d,omitempty"`
+	MaxDowns    int      `msgpack:"D,omitempty"`
+	MinArmor    int      `msgpack:"a,omitempty"`
+	MaxArmor    int      `msgpack:"A,omitempty"`
+	DodgeChance float64  `msgpack:"o,omitempty"` // Chance to dodge a damage entirely.
+	Statuses    Statuses `msgpack:"S,omitempty"` // Timed statuses, such as poison.
 }
 
 // CalculateFromObject calculates hurtable values from an object.
@@ -21,8 +24,16 @@ func (h *Hurtable) CalculateFromObject(o Object) {
 	case *Character:
 		h.CalculateFromCharacter(o)
 	case *Door:
-		h.Health = 10
 		h.MaxHealth = 10
+		if a, ok := o.Archetype.(DoorArchetype); ok && a.MaxHealth > 0 {
+			h.MaxHealth = a.MaxHealth
+		}
+		h.Health = h.MaxHealth
+	case *Bag:
+		if a, ok := o.Archetype.(BagArchetype); ok {
+			h.MaxHealth = a.MaxHealth
+		}
+		h.Health = h.MaxHealth
 	}
 }
 
@@ -36,6 +47,15 @@ func (h *Hurtable) CalculateFromCharacter(c *Character) {
 	h.MaxDowns = 1 + int(c.Funk()/4)
 
 	h.HealthRegen = 1 + int(c.Zooms()+c.Funk()/2+c.Swole()/4)/3
+	for _, t := range c.Archetype.(CharacterArchetype).Traits {
+		h.HealthRegen = t.AdjustRegen(h.HealthRegen)
+	}
+
+	// 2% dodge per point of Zooms, capped at 50%.
+	h.DodgeChance = float64(c.Zooms()) * 0.02
+	if h.DodgeChance > 0.5 {
+		h.DodgeChance = 0.5
+	}
 }
 
 // CalculateArmorFromCharacter calculates the armor from a character.
@@ -82,12 +102,36 @@ func (h *Hurtable) TakeHeal(heal int) bool {
 	return true
 }
 
-// TakeDamages takes damages and applies them to the character. This will increment downs if health is below 0.
+// ResolveDamages resolves rolled damages against the hurtable's dodge chance and armor. Each damage is either dodged or reduced by an armor roll between MinArmor and MaxArmor. The returned results should be passed to TakeDamages.
+func (h *Hurtable) ResolveDamages(damages []DamageResult) (results []DamageResult) {
+	for _, damage := range damages {
+		if rand.Float64() < h.DodgeChance {
+			damage.Absorbed = 0
+			damage.Dodged = true
+			damage.Damage = 0
+			results = append(results, damage)
+			continue
+		}
+		armor := h.MinArmor
+		if h.MaxArmor > h.MinArmor {
+			armor += rand.Intn(h.MaxArmor - h.MinArmor + 1)
+		}
+		if armor > damage.Damage {
+			armor = damage.Damage
+		}
+		damage.Absorbed = armor
+		damage.Damage -= armor
+		results = append(results, damage)
+	}
+	return
+}
+
+// TakeDamages takes damages and applies them to the character. This will increment downs if health is below 0, up to MaxDowns.
 func (h *Hurtable) TakeDamages(damages []DamageResult) {
 	for _, damage := range damages {
 		h.Health -= damage.Damage
 	}
-	if h.Health < 0 {
+	if h.Health < 0 && h.Downs < h.MaxDowns {
 		h.Downs++
 	}
 }
@@ -0,0 +1,89 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveDamages(t *testing.T) {
+	tests := []struct {
+		name     string
+		hurtable Hurtable
+		damages  []DamageResult
+		want     []DamageResult
+	}{
+		{
+			name:     "no armor",
+			hurtable: Hurtable{},
+			damages:  []DamageResult{{Damage: 5}},
+			want:     []DamageResult{{Damage: 5}},
+		},
+		{
+			name:     "armor absorbs",
+			hurtable: Hurtable{MinArmor: 2, MaxArmor: 2},
+			damages:  []DamageResult{{Damage: 5, Critical: true}},
+			want:     []DamageResult{{Damage: 3, Absorbed: 2, Critical: true}},
+		},
+		{
+			name:     "armor absorbs at most the damage",
+			hurtable: Hurtable{MinArmor: 4, MaxArmor: 4},
+			damages:  []DamageResult{{Damage: 3}},
+			want:     []DamageResult{{Damage: 0, Absorbed: 3}},
+		},
+		{
+			name:     "each damage",
+			hurtable: Hurtable{MinArmor: 1, MaxArmor: 1},
+			damages:  []DamageResult{{Damage: 2}, {Damage: 4}},
+			want:     []DamageResult{{Damage: 1, Absorbed: 1}, {Damage: 3, Absorbed: 1}},
+		},
+		{
+			name:     "dodged",
+			hurtable: Hurtable{MinArmor: 1, MaxArmor: 1, DodgeChance: 1},
+			damages:  []DamageResult{{Damage: 5}},
+			want:     []DamageResult{{Dodged: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hurtable.ResolveDamages(tt.damages); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveDamages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveDamagesArmorRange(t *testing.T) {
+	h := Hurtable{MinArmor: 1, MaxArmor: 3}
+	for i := 0; i < 100; i++ {
+		got := h.ResolveDamages([]DamageResult{{Damage: 10}})[0]
+		if got.Absorbed < 1 || got.Absorbed > 3 || got.Damage+got.Absorbed != 10 {
+			t.Fatalf("ResolveDamages() = %+v, want 1 to 3 of 10 damage absorbed", got)
+		}
+	}
+}
+
+func TestTakeDamages(t *testing.T) {
+	tests := []struct {
+		name       string
+		health     int
+		downs      int
+		damages    []DamageResult
+		wantHealth int
+		wantDowns  int
+		wantDead   bool
+	}{
+		{"hurt", 10, 0, []DamageResult{{Damage: 3}, {Damage: 2}}, 5, 0, false},
+		{"downed", 2, 0, []DamageResult{{Damage: 5}}, -3, 1, false},
+		{"dead", 2, 1, []DamageResult{{Damage: 5}}, -3, 2, true},
+		{"already dead", -3, 2, []DamageResult{{Damage: 5}}, -8, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Hurtable{Health: tt.health, MaxHealth: 10, Downs: tt.downs, MaxDowns: 2}
+			h.TakeDamages(tt.damages)
+			if h.Health != tt.wantHealth || h.Downs != tt.wantDowns || h.IsDead() != tt.wantDead {
+				t.Errorf("Health, Downs, IsDead() = %d, %d, %v, want %d, %d, %v", h.Health, h.Downs, h.IsDead(), tt.wantHealth, tt.wantDowns, tt.wantDead)
+			}
+		})
+	}
+}
@@ -4,9 +4,15 @@ import "github.com/kettek/morogue/id"
 
 // ItemArchetype is effectively a blueprint for an item.
 type ItemArchetype struct {
-	ID    id.UUID
-	Title string `msgpack:"T,omitempty"`
-	Image string `msgpack:"i,omitempty"`
+	ID           id.UUID
+	Title        string  `msgpack:"T,omitempty"`
+	Unidentified string  `msgpack:"u,omitempty"` // Title shown until the item is identified. Items without one are always identified.
+	Description  string  `msgpack:"d,omitempty"`
+	Image        string  `msgpack:"i,omitempty"`
+	Weight       int     `msgpack:"W,omitempty"` // Weight when carried.
+	Light        Light   `msgpack:"L,omitempty"` // Light emitted by the item, whether carried or on the ground.
+	Effects      Effects `msgpack:"e,omitempty"` // Effects of using the item.
+	Charges      int     `msgpack:"c,omitempty"` // Number of uses before the item is used up. 0 means a single use.
 }
 
 // Type returns "item".
@@ -19,14 +25,60 @@ func (a ItemArchetype) GetID() id.UUID {
 	return a.ID
 }
 
+// GetWeight returns the weight.
+func (a ItemArchetype) GetWeight() int {
+	return a.Weight
+}
+
+// GetLight returns the light emitted.
+func (a ItemArchetype) GetLight() Light {
+	return a.Light
+}
+
+// Usable returns true if the item has effects when used.
+func (a ItemArchetype) Usable() bool {
+	return len(a.Effects) > 0
+}
+
+// MaxCharges returns the number of uses a fresh item has.
+func (a ItemArchetype) MaxCharges() int {
+	if a.Charges <= 0 {
+		return 1
+	}
+	return a.Charges
+}
+
 // Item represents a generic item in the world.
 type Item struct {
 	Objectable
 	Position
-	Name string `msgpack:"n,omitempty"`
+	Name       string `msgpack:"n,omitempty"`
+	Charges    int    `msgpack:"c,omitempty"` // Remaining uses.
+	Identified bool   `msgpack:"I,omitempty"`
 }
 
 // Type returns "item"
 func (o Item) Type() ObjectType {
 	return "item"
 }
+
+// Title returns the title of the item as it is known, which is its unidentified title if it has yet to be identified.
+func (o *Item) Title() string {
+	a, ok := o.Archetype.(ItemArchetype)
+	if !ok {
+		return o.Name
+	}
+	if !o.Identified && a.Unidentified != "" {
+		return a.Unidentified
+	}
+	return a.Title
+}
+
+// Use spends a charge of the item, returning false if it has none left.
+func (o *Item) Use() bool {
+	if o.Charges <= 0 {
+		return false
+	}
+	o.Charges--
+	return true
+}
@@ -0,0 +1,69 @@
+package game
+
+import "github.com/kettek/morogue/id"
+
+// KeyArchetype is the archetype for keys and lockpicks.
+type KeyArchetype struct {
+	ID          id.UUID
+	Title       string `msgpack:"T,omitempty"`
+	Description string `msgpack:"d,omitempty"`
+	Image       string `msgpack:"i,omitempty"`
+	LockID      string `msgpack:"k,omitempty"` // The lock ID the key fits.
+	Lockpick    bool   `msgpack:"p,omitempty"` // Lockpicks fit no lock, but can be used to try picking any lock.
+	Weight      int    `msgpack:"W,omitempty"` // Weight when carried.
+}
+
+// Type returns "key".
+func (a KeyArchetype) Type() string {
+	return "key"
+}
+
+// GetID returns the ID of the archetype.
+func (a KeyArchetype) GetID() id.UUID {
+	return a.ID
+}
+
+// GetWeight returns the weight.
+func (a KeyArchetype) GetWeight() int {
+	return a.Weight
+}
+
+// Fits returns true if the key fits the given lock ID.
+func (a KeyArchetype) Fits(lockID string) bool {
+	return !a.Lockpick && a.LockID != "" && a.LockID == lockID
+}
+
+// Key is a key or lockpick in the world.
+type Key struct {
+	Objectable
+	Position
+}
+
+// Type returns "key".
+func (o Key) Type() ObjectType {
+	return "key"
+}
+
+// KeyFor returns the first key in the character's inventory that fits the given lock ID, if any.
+func (c *Character) KeyFor(lockID string) *Key {
+	for _, o := range c.Inventory {
+		if k, ok := o.(*Key); ok {
+			if a, ok := k.Archetype.(KeyArchetype); ok && a.Fits(lockID) {
+				return k
+			}
+		}
+	}
+	return nil
+}
+
+// Lockpick returns the first lockpick in the character's inventory, if any.
+func (c *Character) Lockpick() *Key {
+	for _, o := range c.Inventory {
+		if k, ok := o.(*Key); ok {
+			if a, ok := k.Archetype.(KeyArchetype); ok && a.Lockpick {
+				return k
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package game
+
+import "math"
+
+// MinVisibleLight is the light level needed to see a cell that isn't right next to the viewer.
+const MinVisibleLight = 0.15
+
+// Light is light emitted by a tile or object.
+type Light struct {
+	Radius int      `msgpack:"r,omitempty"` // How far the light reaches.
+	Color  [3]uint8 `msgpack:"c,omitempty"` // Red, green, and blue of the light.
+}
+
+// Lit is implemented by archetypes that can emit light.
+type Lit interface {
+	GetLight() Light
+}
+
+// ObjectLight returns the light emitted by the object, if any. Lights that must be equipped, such as torches, only shine from a character when applied.
+func ObjectLight(o Object) (Light, bool) {
+	a, ok := o.GetArchetype().(Lit)
+	if !ok {
+		return Light{}, false
+	}
+	light := a.GetLight()
+	if light.Radius <= 0 {
+		return Light{}, false
+	}
+	if ap, ok := o.(interface{ IsApplied() bool }); ok && o.GetContainerWID() != 0 && !ap.IsApplied() {
+		return Light{}, false
+	}
+	return light, true
+}
+
+// LightSource is a light shining from a position.
+type LightSource struct {
+	Position
+	Light
+}
+
+// Illumination is the light falling on a cell, with each channel ranging from 0 to 1.
+type Illumination struct {
+	R, G, B float64
+}
+
+// Level returns the brightness of the illumination.
+func (i Illumination) Level() float64 {
+	return math.Max(i.R, math.Max(i.G, i.B))
+}
+
+// Lighting is the illumination of each cell in a location.
+type Lighting [][]Illumination
+
+// At returns the illumination at the given position.
+func (l Lighting) At(p Position) Illumination {
+	if p.X < 0 || p.Y < 0 || p.X >= len(l) || p.Y >= len(l[p.X]) {
+		return Illumination{}
+	}
+	return l[p.X][p.Y]
+}
+
+// Lighting returns the illumination of the cells from the given ambient light and light sources. Light fades with distance from its source and does not pass through opaque cells.
+func (c Cells) Lighting(ambient func(x, y int) float64, sources []LightSource) Lighting {
+	l := make(Lighting, len(c))
+	for x := range c {
+		l[x] = make([]Illumination, len(c[x]))
+		for y := range c[x] {
+			a := ambient(x, y)
+			l[x][y] = Illumination{a, a, a}
+		}
+	}
+	for _, s := range sources {
+		v := c.FOV(s.Position, s.Radius)
+		for x := s.X - s.Radius; x <= s.X+s.Radius; x++ {
+			for y := s.Y - s.Radius; y <= s.Y+s.Radius; y++ {
+				p := Position{X: x, Y: y}
+				if !v.Visible(p) {
+					continue
+				}
+				dx, dy := float64(x-s.X), float64(y-s.Y)
+				f := 1 - math.Sqrt(dx*dx+dy*dy)/float64(s.Radius+1)
+				if f <= 0 {
+					continue
+				}
+				i := &l[x][y]
+				i.R = math.Min(1, i.R+f*float64(s.Color[0])/255)
+				i.G = math.Min(1, i.G+f*float64(s.Color[1])/255)
+				i.B = math.Min(1, i.B+f*float64(s.Color[2])/255)
+			}
+		}
+	}
+	return l
+}
+
+// Lit removes cells from the visibility that are too dark to see. Cells right next to the origin can always be seen, as they can be felt out.
+func (v Visibility) Lit(origin Position, lighting Lighting) Visibility {
+	if lighting == nil {
+		return v
+	}
+	for x := range v {
+		for y := range v[x] {
+			if !v[x][y] {
+				continue
+			}
+			if x-origin.X <= 1 && origin.X-x <= 1 && y-origin.Y <= 1 && origin.Y-y <= 1 {
+				continue
+			}
+			if lighting.At(Position{X: x, Y: y}).Level() < MinVisibleLight {
+				v[x][y] = false
+			}
+		}
+	}
+	return v
+}
@@ -0,0 +1,35 @@
+package game
+
+// Line returns the positions along a line from one position to another, excluding the starting position. This uses Bresenham's line algorithm.
+func Line(from, to Position) (positions []Position) {
+	dx := to.X - from.X
+	if dx < 0 {
+		dx = -dx
+	}
+	dy := -(to.Y - from.Y)
+	if dy > 0 {
+		dy = -dy
+	}
+	sx, sy := 1, 1
+	if from.X > to.X {
+		sx = -1
+	}
+	if from.Y > to.Y {
+		sy = -1
+	}
+	err := dx + dy
+	x, y := from.X, from.Y
+	for x != to.X || y != to.Y {
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y += sy
+		}
+		positions = append(positions, Position{X: x, Y: y})
+	}
+	return
+}
@@ -0,0 +1,29 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to Position
+		want     []Position
+	}{
+		{"same position", Position{X: 2, Y: 2}, Position{X: 2, Y: 2}, nil},
+		{"adjacent", Position{X: 2, Y: 2}, Position{X: 3, Y: 2}, []Position{{X: 3, Y: 2}}},
+		{"horizontal", Position{X: 0, Y: 0}, Position{X: 3, Y: 0}, []Position{{X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 0}}},
+		{"vertical up", Position{X: 1, Y: 3}, Position{X: 1, Y: 0}, []Position{{X: 1, Y: 2}, {X: 1, Y: 1}, {X: 1, Y: 0}}},
+		{"diagonal", Position{X: 0, Y: 0}, Position{X: 3, Y: 3}, []Position{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}}},
+		{"diagonal back", Position{X: 3, Y: 0}, Position{X: 0, Y: 3}, []Position{{X: 2, Y: 1}, {X: 1, Y: 2}, {X: 0, Y: 3}}},
+		{"shallow", Position{X: 0, Y: 0}, Position{X: 4, Y: 2}, []Position{{X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 2}, {X: 4, Y: 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Line(tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Line(%v, %v) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
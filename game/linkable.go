@@ -0,0 +1,31 @@
+package game
+
+import "github.com/kettek/morogue/id"
+
+// Linkable is an embed that provides logic for linking to an object in another location.
+type Linkable struct {
+	LinkLocation id.UUID `msgpack:"-"` // The location this links to. Nil if the target has not yet been generated.
+	LinkWID      id.WID  `msgpack:"-"` // The object in the linked location that this arrives at.
+}
+
+// IsLinked returns true if the linkable has a target location.
+func (l *Linkable) IsLinked() bool {
+	return !l.LinkLocation.IsNil()
+}
+
+// Link sets the target location and object of the linkable.
+func (l *Linkable) Link(location id.UUID, wid id.WID) {
+	l.LinkLocation = location
+	l.LinkWID = wid
+}
+
+// Unlink clears the target of the linkable.
+func (l *Linkable) Unlink() {
+	l.LinkLocation = id.UUID{}
+	l.LinkWID = 0
+}
+
+// Linked returns the target location and object of the linkable.
+func (l *Linkable) Linked() (id.UUID, id.WID) {
+	return l.LinkLocation, l.LinkWID
+}
@@ -9,6 +9,7 @@ type Location struct {
 	ID      id.UUID `msgpack:"id,omitempty"`
 	Cells   Cells   `msgpack:"c,omitempty"`
 	Objects Objects `msgpack:"o,omitempty"`
+	Ambient float64 `msgpack:"a,omitempty"` // Ambient light level, from 0 for pitch black to 1 for broad daylight.
 }
 
 // Character returns a Character associated with wid.
@@ -40,3 +41,64 @@ func (l *Location) ObjectByWID(wid id.WID) Object {
 	}
 	return nil
 }
+
+// LightSources returns the lights shining from the location's objects. Lights carried by characters shine from them, while lights inside of bags are covered up.
+func (l *Location) LightSources() (sources []LightSource) {
+	for _, o := range l.Objects {
+		light, ok := ObjectLight(o)
+		if !ok {
+			continue
+		}
+		p := o.GetPosition()
+		if wid := o.GetContainerWID(); wid != 0 {
+			c := l.Character(wid)
+			if c == nil {
+				continue
+			}
+			p = c.Position
+		}
+		sources = append(sources, LightSource{Position: p, Light: light})
+	}
+	return
+}
+
+// Lighting returns the illumination of the location's cells from its ambient light, lit tiles, and lit objects. Tiles are looked up with the given func, as the location does not know of archetypes.
+func (l *Location) Lighting(tile func(id.UUID) (TileArchetype, bool)) Lighting {
+	ambient := l.TileAmbient(tile)
+	return l.Cells.Lighting(func(x, y int) float64 {
+		return ambient[x][y]
+	}, append(l.LightSources(), l.TileLightSources(tile)...))
+}
+
+// TileLightSources returns the lights shining from the location's lit tiles.
+func (l *Location) TileLightSources(tile func(id.UUID) (TileArchetype, bool)) (sources []LightSource) {
+	for x := range l.Cells {
+		for y, c := range l.Cells[x] {
+			if c.TileID == nil {
+				continue
+			}
+			if t, ok := tile(*c.TileID); ok && t.Light.Radius > 0 {
+				sources = append(sources, LightSource{Position: Position{X: x, Y: y}, Light: t.Light})
+			}
+		}
+	}
+	return
+}
+
+// TileAmbient returns the ambient light of each cell, which is the location's own unless the cell's tile has its own.
+func (l *Location) TileAmbient(tile func(id.UUID) (TileArchetype, bool)) [][]float64 {
+	ambient := make([][]float64, len(l.Cells))
+	for x := range l.Cells {
+		ambient[x] = make([]float64, len(l.Cells[x]))
+		for y, c := range l.Cells[x] {
+			ambient[x][y] = l.Ambient
+			if c.TileID == nil {
+				continue
+			}
+			if t, ok := tile(*c.TileID); ok && t.Ambient != nil {
+				ambient[x][y] = *t.Ambient
+			}
+		}
+	}
+	return ambient
+}
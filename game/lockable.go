@@ -2,7 +2,9 @@ package game
 
 // Lockable is a feature that enables locking and unlocking
 type Lockable struct {
-	Locked bool `msgpack:"l"`
+	Locked     bool   `msgpack:"l"`
+	LockID     string `msgpack:"k,omitempty"` // Keys with a matching lock ID fit the lock. Locks without one can only be picked.
+	Difficulty int    `msgpack:"-"`           // How hard the lock is to pick.
 }
 
 // Lock locks the lockable
@@ -19,3 +21,40 @@ func (l *Lockable) Unlock() {
 func (l *Lockable) IsLocked() bool {
 	return l.Locked
 }
+
+// GetLockID returns the lock ID that keys must match.
+func (l *Lockable) GetLockID() string {
+	return l.LockID
+}
+
+// GetDifficulty returns how hard the lock is to pick.
+func (l *Lockable) GetDifficulty() int {
+	return l.Difficulty
+}
+
+// SetLock locks the lockable with the given lock ID and picking difficulty.
+func (l *Lockable) SetLock(lockID string, difficulty int) {
+	l.LockID = lockID
+	l.Difficulty = difficulty
+	l.Locked = true
+}
+
+// PickChance returns the chance of the character picking a lock of the given difficulty. Brains and lockpicking skill improve the chance, though there is always some chance to succeed or fail.
+func PickChance(c *Character, difficulty int) float64 {
+	chance := 0.2 + float64(c.Brains())*0.05 + float64(c.Skills.Level(SkillLockpicking))*0.1 - float64(difficulty)*0.1
+	if chance < 0.05 {
+		return 0.05
+	} else if chance > 0.95 {
+		return 0.95
+	}
+	return chance
+}
+
+// PickBreakChance returns the chance of a lockpick breaking on a failed attempt. Lockpicking skill makes this less likely.
+func PickBreakChance(c *Character) float64 {
+	chance := 0.5 - float64(c.Skills.Level(SkillLockpicking))*0.1
+	if chance < 0.05 {
+		return 0.05
+	}
+	return chance
+}
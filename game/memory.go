@@ -0,0 +1,68 @@
+package game
+
+import "github.com/kettek/morogue/id"
+
+// Memory is a character's memory of the cells they have seen in each location they have visited.
+type Memory []LocationMemory
+
+// LocationMemory is the memory of the cells seen in a single location.
+type LocationMemory struct {
+	Location id.UUID
+	Width    int
+	Height   int
+	Seen     []byte // Bitset of seen cells, stored column by column.
+}
+
+// Location returns the memory of the given location, creating it if it does not exist. The memory is cleared if the location's size no longer matches, as the location must have changed.
+func (m *Memory) Location(location id.UUID, width, height int) *LocationMemory {
+	for i := range *m {
+		lm := &(*m)[i]
+		if lm.Location != location {
+			continue
+		}
+		if lm.Width != width || lm.Height != height {
+			*lm = newLocationMemory(location, width, height)
+		}
+		return lm
+	}
+	*m = append(*m, newLocationMemory(location, width, height))
+	return &(*m)[len(*m)-1]
+}
+
+func newLocationMemory(location id.UUID, width, height int) LocationMemory {
+	return LocationMemory{
+		Location: location,
+		Width:    width,
+		Height:   height,
+		Seen:     make([]byte, (width*height+7)/8),
+	}
+}
+
+// Remembers returns true if the cell at the given position has been seen.
+func (lm *LocationMemory) Remembers(p Position) bool {
+	if p.X < 0 || p.Y < 0 || p.X >= lm.Width || p.Y >= lm.Height {
+		return false
+	}
+	i := p.X*lm.Height + p.Y
+	return lm.Seen[i/8]&(1<<(i%8)) != 0
+}
+
+// Remember marks the cell at the given position as seen.
+func (lm *LocationMemory) Remember(p Position) {
+	if p.X < 0 || p.Y < 0 || p.X >= lm.Width || p.Y >= lm.Height {
+		return
+	}
+	i := p.X*lm.Height + p.Y
+	lm.Seen[i/8] |= 1 << (i % 8)
+}
+
+// RememberVisibility marks all visible cells as seen.
+func (lm *LocationMemory) RememberVisibility(v Visibility) {
+	for x := range v {
+		for y := range v[x] {
+			if v[x][y] {
+				lm.Remember(Position{X: x, Y: y})
+			}
+		}
+	}
+}
@@ -11,4 +11,17 @@ func (m *Movable) CalculateFromCharacter(c *Character) {
 	value := c.Archetype.(CharacterArchetype).Zooms + c.Archetype.(CharacterArchetype).Funk/4
 	value += c.Attributes.Zooms + c.Attributes.Funk/4
 	m.Actions = 1 + int(value)/4
+	m.Actions += c.Statuses.Potency(StatusHaste) - c.Statuses.Potency(StatusSlow)
+	m.Actions -= c.HungerState.ActionPenalty()
+	m.Actions -= c.Encumbrance.ActionPenalty()
+	for _, o := range c.Inventory {
+		if a, ok := o.(*Armor); ok && a.Applied {
+			if arch, ok := a.Archetype.(ArmorArchetype); ok {
+				m.Actions -= arch.MovePenalty
+			}
+		}
+	}
+	if m.Actions < 1 {
+		m.Actions = 1
+	}
 }
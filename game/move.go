@@ -26,6 +26,29 @@ func (d MoveDirection) Position() (int, int) {
 	return 0, 0
 }
 
+// MoveDirectionFromPosition returns the MoveDirection for the given x and y direction.
+func MoveDirectionFromPosition(x, y int) MoveDirection {
+	switch {
+	case x < 0 && y < 0:
+		return UpLeftMoveDirection
+	case x > 0 && y < 0:
+		return UpRightMoveDirection
+	case x < 0 && y > 0:
+		return DownLeftMoveDirection
+	case x > 0 && y > 0:
+		return DownRightMoveDirection
+	case x < 0:
+		return LeftMoveDirection
+	case x > 0:
+		return RightMoveDirection
+	case y < 0:
+		return UpMoveDirection
+	case y > 0:
+		return DownMoveDirection
+	}
+	return CenterMoveDirection
+}
+
 // Our movement directions.
 const (
 	UpMoveDirection        MoveDirection = 8
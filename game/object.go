@@ -38,6 +38,14 @@ func CreateObjectFromArchetype(a Archetype) Object {
 			},
 			Slots: a.Slots.ToMap(),
 		}
+	case ItemArchetype:
+		return &Item{
+			Objectable: Objectable{
+				ArchetypeID: a.GetID(),
+				Archetype:   a,
+			},
+			Charges: a.MaxCharges(),
+		}
 	case WeaponArchetype:
 		return &Weapon{
 			Objectable: Objectable{
@@ -53,7 +61,7 @@ func CreateObjectFromArchetype(a Archetype) Object {
 			},
 		}
 	case DoorArchetype:
-		return &Door{
+		d := &Door{
 			Objectable: Objectable{
 				ArchetypeID: a.GetID(),
 				Archetype:   a,
@@ -62,6 +70,8 @@ func CreateObjectFromArchetype(a Archetype) Object {
 				BlockType: a.BlockType,
 			},
 		}
+		d.Hurtable.CalculateFromObject(d)
+		return d
 	case FoodArchetype:
 		return &Food{
 			Objectable: Objectable{
@@ -74,7 +84,7 @@ func CreateObjectFromArchetype(a Archetype) Object {
 			},
 		}
 	case BagArchetype:
-		return &Bag{
+		b := &Bag{
 			Objectable: Objectable{
 				ArchetypeID: a.GetID(),
 				Archetype:   a,
@@ -84,6 +94,30 @@ func CreateObjectFromArchetype(a Archetype) Object {
 				Limit:    a.Limit,
 			},
 		}
+		b.Hurtable.CalculateFromObject(b)
+		return b
+	case StairsArchetype:
+		return &Stairs{
+			Objectable: Objectable{
+				ArchetypeID: a.GetID(),
+				Archetype:   a,
+			},
+		}
+	case KeyArchetype:
+		return &Key{
+			Objectable: Objectable{
+				ArchetypeID: a.GetID(),
+				Archetype:   a,
+			},
+		}
+	case TrapArchetype:
+		return &Trap{
+			Objectable: Objectable{
+				ArchetypeID: a.GetID(),
+				Archetype:   a,
+			},
+			Revealed: a.Obvious,
+		}
 	}
 	return nil
 }
@@ -151,6 +185,36 @@ func (ow ObjectWrapper) Object() (Object, error) {
 			return nil, err
 		}
 		return f, nil
+	case (Stairs{}).Type():
+		var s *Stairs
+		if err := msgpack.Unmarshal(ow.Data, &s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case (&Bag{}).Type():
+		var b *Bag
+		if err := msgpack.Unmarshal(ow.Data, &b); err != nil {
+			return nil, err
+		}
+		return b, nil
+	case (&Door{}).Type():
+		var d *Door
+		if err := msgpack.Unmarshal(ow.Data, &d); err != nil {
+			return nil, err
+		}
+		return d, nil
+	case (Key{}).Type():
+		var k *Key
+		if err := msgpack.Unmarshal(ow.Data, &k); err != nil {
+			return nil, err
+		}
+		return k, nil
+	case (Trap{}).Type():
+		var t *Trap
+		if err := msgpack.Unmarshal(ow.Data, &t); err != nil {
+			return nil, err
+		}
+		return t, nil
 	}
 	return nil, errors.New("unknown object type: " + string(ow.Type))
 }
@@ -189,6 +253,36 @@ func (ow ObjectWrapper) ObjectJSON() (Object, error) {
 			return nil, err
 		}
 		return f, nil
+	case (Stairs{}).Type():
+		var s *Stairs
+		if err := json.Unmarshal(ow.Data, &s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case (&Bag{}).Type():
+		var b *Bag
+		if err := json.Unmarshal(ow.Data, &b); err != nil {
+			return nil, err
+		}
+		return b, nil
+	case (&Door{}).Type():
+		var d *Door
+		if err := json.Unmarshal(ow.Data, &d); err != nil {
+			return nil, err
+		}
+		return d, nil
+	case (Key{}).Type():
+		var k *Key
+		if err := json.Unmarshal(ow.Data, &k); err != nil {
+			return nil, err
+		}
+		return k, nil
+	case (Trap{}).Type():
+		var t *Trap
+		if err := json.Unmarshal(ow.Data, &t); err != nil {
+			return nil, err
+		}
+		return t, nil
 	}
 	return nil, errors.New("unknown object type: " + string(ow.Type))
 }
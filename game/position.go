@@ -16,3 +16,35 @@ func (o *Position) SetPosition(p Position) {
 	o.X = p.X
 	o.Y = p.Y
 }
+
+// Distance returns the number of steps between two positions, counting diagonals as a single step.
+func (o Position) Distance(p Position) int {
+	dx := p.X - o.X
+	if dx < 0 {
+		dx = -dx
+	}
+	dy := p.Y - o.Y
+	if dy < 0 {
+		dy = -dy
+	}
+	if dx > dy {
+		return dx
+	}
+	return dy
+}
+
+// DirectionTo returns the MoveDirection of a single step towards the given position.
+func (o Position) DirectionTo(p Position) MoveDirection {
+	x, y := 0, 0
+	if p.X < o.X {
+		x = -1
+	} else if p.X > o.X {
+		x = 1
+	}
+	if p.Y < o.Y {
+		y = -1
+	} else if p.Y > o.Y {
+		y = 1
+	}
+	return MoveDirectionFromPosition(x, y)
+}
@@ -1,4 +1,99 @@
 package game
 
-// Skills is a mapping of skill names to a float.
+import "math"
+
+// Skills is a mapping of skill names to a float. Like AttributeLevel, the whole number is the skill's level and the fractional is the progress towards the next.
 type Skills map[string]float64
+
+// Level returns the whole level of the named skill.
+func (s Skills) Level(name string) int {
+	return int(s[name])
+}
+
+// Skill describes a skill that characters can grow in through use.
+type Skill struct {
+	Name        string
+	Description string
+	Growth      float64 // Progress gained per use, reduced by the skill's current level.
+}
+
+// Our skill names.
+const (
+	SkillMelee       = "melee"
+	SkillRange       = "range"
+	SkillThrown      = "thrown"
+	SkillUnarmed     = "unarmed"
+	SkillLockpicking = "lockpicking"
+	SkillCooking     = "cooking"
+)
+
+// skills is our registry of skills by name.
+var skills = map[string]Skill{
+	SkillMelee: {
+		Name:        SkillMelee,
+		Description: "Proficiency with melee weapons raises their min damage up to max",
+		Growth:      0.1,
+	},
+	SkillRange: {
+		Name:        SkillRange,
+		Description: "Proficiency with ranged weapons raises their min damage up to max",
+		Growth:      0.1,
+	},
+	SkillThrown: {
+		Name:        SkillThrown,
+		Description: "Proficiency with thrown weapons raises their min damage up to max",
+		Growth:      0.1,
+	},
+	SkillUnarmed: {
+		Name:        SkillUnarmed,
+		Description: "Proficiency with fists and feet raises their min damage up to max",
+		Growth:      0.1,
+	},
+	SkillLockpicking: {
+		Name:        SkillLockpicking,
+		Description: "Lockpicking improves the chance to pick locks without breaking picks",
+		Growth:      0.2,
+	},
+	SkillCooking: {
+		Name:        SkillCooking,
+		Description: "Cooking gets 10% more calories out of food per level",
+		Growth:      0.05,
+	},
+}
+
+// SkillByName returns the registered skill with the given name.
+func SkillByName(name string) (Skill, bool) {
+	s, ok := skills[name]
+	return s, ok
+}
+
+// WeaponSkill returns the name of the proficiency skill for the given weapon type.
+func WeaponSkill(w WeaponType) string {
+	switch w {
+	case WeaponTypeMelee:
+		return SkillMelee
+	case WeaponTypeRange:
+		return SkillRange
+	case WeaponTypeThrown:
+		return SkillThrown
+	case WeaponTypeUnarmed:
+		return SkillUnarmed
+	default:
+		return ""
+	}
+}
+
+// TrainSkill grows the character's named skill from a use. It returns true if the skill reached a new level. Unknown skills are ignored.
+func (c *Character) TrainSkill(name string) bool {
+	skill, ok := SkillByName(name)
+	if !ok {
+		return false
+	}
+	if c.Skills == nil {
+		c.Skills = make(Skills)
+	}
+	before := math.Floor(c.Skills[name])
+	c.Skills[name] += skill.Growth / (1 + before)
+	c.SkillsChanged = true
+	return math.Floor(c.Skills[name]) > before
+}
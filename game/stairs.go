@@ -0,0 +1,69 @@
+package game
+
+import "github.com/kettek/morogue/id"
+
+// StairsDirection is the direction a set of stairs leads.
+type StairsDirection int
+
+// Our stairs directions.
+const (
+	StairsDirectionNone StairsDirection = 0
+	StairsDirectionDown StairsDirection = 1
+	StairsDirectionUp   StairsDirection = -1
+)
+
+// UnmarshalJSON unmarshals a string into our StairsDirection.
+func (d *StairsDirection) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `"down"`:
+		*d = StairsDirectionDown
+	case `"up"`:
+		*d = StairsDirectionUp
+	default:
+		*d = StairsDirectionNone
+	}
+	return nil
+}
+
+// Opposite returns the direction that leads back from this direction.
+func (d StairsDirection) Opposite() StairsDirection {
+	return -d
+}
+
+// StairsArchetype is the archetype for stairs and portals that lead to other locations.
+type StairsArchetype struct {
+	ID          id.UUID
+	Title       string          `msgpack:"T,omitempty"`
+	Description string          `msgpack:"d,omitempty"`
+	Image       string          `msgpack:"i,omitempty"`
+	Direction   StairsDirection `msgpack:"D,omitempty"` // Depth change when used. None is used for portals that stay at the same depth.
+	Place       id.UUID         `msgpack:"-"`           // Place to generate the target location from. If nil, a place is chosen by depth.
+	Weight      int             `msgpack:"W,omitempty"` // Weight when carried.
+}
+
+// Type returns "stairs".
+func (a StairsArchetype) Type() string {
+	return "stairs"
+}
+
+// GetID returns the ID of the archetype.
+func (a StairsArchetype) GetID() id.UUID {
+	return a.ID
+}
+
+// GetWeight returns the weight.
+func (a StairsArchetype) GetWeight() int {
+	return a.Weight
+}
+
+// Stairs are stairs or portals that move characters between locations.
+type Stairs struct {
+	Objectable
+	Position
+	Linkable
+}
+
+// Type returns "stairs".
+func (o Stairs) Type() ObjectType {
+	return "stairs"
+}
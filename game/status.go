@@ -0,0 +1,135 @@
+package game
+
+import "math/rand"
+
+// Our status names.
+const (
+	StatusPoison       = "poison"       // Loses Potency health each turn.
+	StatusRegeneration = "regeneration" // Gains Potency health each turn.
+	StatusHaste        = "haste"        // Gains Potency actions per turn.
+	StatusSlow         = "slow"         // Loses Potency actions per turn.
+)
+
+// StatusStacking is the rule for how a status combines with an active status of the same name.
+type StatusStacking uint8
+
+// Our status stacking rules.
+const (
+	StatusStackingRefresh   StatusStacking = iota // The longer of the two durations is kept.
+	StatusStackingExtend                          // The durations are added together.
+	StatusStackingIntensify                       // The potencies are added together and the longer duration is kept.
+	StatusStackingIgnore                          // The new status is ignored while the old one is active.
+)
+
+// UnmarshalJSON unmarshals the JSON representation of the stacking rule.
+func (s *StatusStacking) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case `"extend"`:
+		*s = StatusStackingExtend
+	case `"intensify"`:
+		*s = StatusStackingIntensify
+	case `"ignore"`:
+		*s = StatusStackingIgnore
+	default:
+		*s = StatusStackingRefresh
+	}
+	return nil
+}
+
+// Status is a timed condition on a hurtable object, such as poison or haste.
+type Status struct {
+	Name     string         `msgpack:"n"`
+	Duration int            `msgpack:"d,omitempty"` // Remaining duration in turns.
+	Potency  int            `msgpack:"p,omitempty"` // Strength of the status, such as damage per turn.
+	Stacking StatusStacking `msgpack:"s,omitempty"`
+	Chance   float64        `msgpack:"-"` // Chance of the status being inflicted when declared on an archetype. 0 means always.
+}
+
+// Roll returns true if the status should be inflicted, as per its chance.
+func (s Status) Roll() bool {
+	return s.Chance <= 0 || rand.Float64() < s.Chance
+}
+
+// Statuses is a list of statuses.
+type Statuses []Status
+
+// Potency returns the total potency of the named status, or 0 if it is not active.
+func (s Statuses) Potency(name string) (potency int) {
+	for _, status := range s {
+		if status.Name == name {
+			potency += status.Potency
+		}
+	}
+	return
+}
+
+// AddStatus adds a status, stacking it with an active status of the same name by the new status' stacking rule. It returns the resulting status and whether anything changed.
+func (h *Hurtable) AddStatus(s Status) (Status, bool) {
+	for i, status := range h.Statuses {
+		if status.Name != s.Name {
+			continue
+		}
+		switch s.Stacking {
+		case StatusStackingIgnore:
+			return status, false
+		case StatusStackingExtend:
+			status.Duration += s.Duration
+		case StatusStackingIntensify:
+			status.Potency += s.Potency
+			fallthrough
+		default:
+			if s.Duration > status.Duration {
+				status.Duration = s.Duration
+			}
+		}
+		h.Statuses[i] = status
+		return status, true
+	}
+	s.Chance = 0
+	h.Statuses = append(h.Statuses, s)
+	return s, true
+}
+
+// SetStatus sets the status, replacing any active status of the same name.
+func (h *Hurtable) SetStatus(s Status) {
+	for i, status := range h.Statuses {
+		if status.Name == s.Name {
+			h.Statuses[i] = s
+			return
+		}
+	}
+	h.Statuses = append(h.Statuses, s)
+}
+
+// RemoveStatus removes the named status.
+func (h *Hurtable) RemoveStatus(name string) bool {
+	for i, status := range h.Statuses {
+		if status.Name == name {
+			h.Statuses = append(h.Statuses[:i], h.Statuses[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// TickStatuses applies each status' effect for a turn and reduces its duration. It returns the statuses that ticked and the statuses that expired, with the expired statuses being removed.
+func (h *Hurtable) TickStatuses() (ticked Statuses, expired Statuses) {
+	var remaining Statuses
+	for _, status := range h.Statuses {
+		switch status.Name {
+		case StatusPoison:
+			h.TakeDamages([]DamageResult{{Damage: status.Potency}})
+		case StatusRegeneration:
+			h.TakeHeal(status.Potency)
+		}
+		status.Duration--
+		if status.Duration <= 0 {
+			expired = append(expired, status)
+			continue
+		}
+		ticked = append(ticked, status)
+		remaining = append(remaining, status)
+	}
+	h.Statuses = remaining
+	return
+}
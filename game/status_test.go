@@ -0,0 +1,87 @@
+package game
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddStatus(t *testing.T) {
+	active := Status{Name: StatusPoison, Duration: 3, Potency: 2}
+	tests := []struct {
+		name        string
+		add         Status
+		want        Status
+		wantChanged bool
+	}{
+		{"refresh longer", Status{Name: StatusPoison, Duration: 5, Potency: 4}, Status{Name: StatusPoison, Duration: 5, Potency: 2}, true},
+		{"refresh shorter", Status{Name: StatusPoison, Duration: 1, Potency: 4}, Status{Name: StatusPoison, Duration: 3, Potency: 2}, true},
+		{"extend", Status{Name: StatusPoison, Duration: 2, Stacking: StatusStackingExtend}, Status{Name: StatusPoison, Duration: 5, Potency: 2}, true},
+		{"intensify", Status{Name: StatusPoison, Duration: 4, Potency: 1, Stacking: StatusStackingIntensify}, Status{Name: StatusPoison, Duration: 4, Potency: 3}, true},
+		{"ignore", Status{Name: StatusPoison, Duration: 9, Potency: 9, Stacking: StatusStackingIgnore}, active, false},
+		{"new status", Status{Name: StatusHaste, Duration: 2, Potency: 1, Chance: 0.5}, Status{Name: StatusHaste, Duration: 2, Potency: 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Hurtable{Statuses: Statuses{active}}
+			got, changed := h.AddStatus(tt.add)
+			if got != tt.want || changed != tt.wantChanged {
+				t.Errorf("AddStatus() = %v, %v, want %v, %v", got, changed, tt.want, tt.wantChanged)
+			}
+		})
+	}
+}
+
+func TestTickStatuses(t *testing.T) {
+	tests := []struct {
+		name          string
+		health        int
+		statuses      Statuses
+		wantHealth    int
+		wantTicked    Statuses
+		wantExpired   Statuses
+		wantRemaining Statuses
+	}{
+		{
+			name:          "poison",
+			health:        10,
+			statuses:      Statuses{{Name: StatusPoison, Duration: 2, Potency: 3}},
+			wantHealth:    7,
+			wantTicked:    Statuses{{Name: StatusPoison, Duration: 1, Potency: 3}},
+			wantRemaining: Statuses{{Name: StatusPoison, Duration: 1, Potency: 3}},
+		},
+		{
+			name:        "regeneration up to max health",
+			health:      9,
+			statuses:    Statuses{{Name: StatusRegeneration, Duration: 1, Potency: 5}},
+			wantHealth:  10,
+			wantExpired: Statuses{{Name: StatusRegeneration, Duration: 0, Potency: 5}},
+		},
+		{
+			name:          "expired and remaining",
+			health:        10,
+			statuses:      Statuses{{Name: StatusHaste, Duration: 1, Potency: 1}, {Name: StatusSlow, Duration: 3, Potency: 1}},
+			wantHealth:    10,
+			wantTicked:    Statuses{{Name: StatusSlow, Duration: 2, Potency: 1}},
+			wantExpired:   Statuses{{Name: StatusHaste, Duration: 0, Potency: 1}},
+			wantRemaining: Statuses{{Name: StatusSlow, Duration: 2, Potency: 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Hurtable{Health: tt.health, MaxHealth: 10, Statuses: tt.statuses}
+			ticked, expired := h.TickStatuses()
+			if h.Health != tt.wantHealth {
+				t.Errorf("Health = %d, want %d", h.Health, tt.wantHealth)
+			}
+			if !reflect.DeepEqual(ticked, tt.wantTicked) {
+				t.Errorf("ticked = %v, want %v", ticked, tt.wantTicked)
+			}
+			if !reflect.DeepEqual(expired, tt.wantExpired) {
+				t.Errorf("expired = %v, want %v", expired, tt.wantExpired)
+			}
+			if !reflect.DeepEqual(h.Statuses, tt.wantRemaining) {
+				t.Errorf("Statuses = %v, want %v", h.Statuses, tt.wantRemaining)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package game
+
+// Tags are a list of data-defined kinds an archetype is considered as, such as "club" or "blade".
+type Tags []string
+
+// Has returns true if the tags contain the given tag.
+func (t Tags) Has(tag string) bool {
+	for _, t2 := range t {
+		if t2 == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAny returns true if the tags contain any of the given tags.
+func (t Tags) HasAny(tags ...string) bool {
+	for _, tag := range tags {
+		if t.Has(tag) {
+			return true
+		}
+	}
+	return false
+}
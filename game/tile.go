@@ -8,8 +8,15 @@ type TileArchetype struct {
 	Title string
 	ID    id.UUID
 
-	Image string // Image for the tile. It should be requested via HTTP to the resources backend.
-	// TODO: Other tile properties.
+	Image    string       // Image for the tile. It should be requested via HTTP to the resources backend.
+	Blocks   MovementType // Movement blocked by the tile.
+	Opaque   bool         // Whether the tile blocks sight.
+	Liquid   bool         // Whether the tile is a liquid, such as water.
+	MoveCost int          // Extra actions spent entering the tile.
+	Damage   int          // Damage taken when entering the tile.
+	Effects  Statuses     // Statuses inflicted when entering the tile.
+	Light    Light        // Light emitted by the tile.
+	Ambient  *float64     // Ambient light level of the tile, overriding the location's. This keeps places such as caves dark even under the sun.
 }
 
 // Type returns "tile".
@@ -2,122 +2,167 @@ package game
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
-
-	"github.com/vmihailenco/msgpack/v5"
+	"math"
 )
 
-// Trait is an interface that all traits must implement.
-type Trait interface {
-	String() string
-	CanApply(o Object) bool
-	AdjustDamages(damages []Damage) []Damage
+// Trait is a data-defined modifier to a character archetype. Traits are loaded from JSON and registered by their name, which is what character archetypes refer to them by.
+type Trait struct {
+	Name              string              `msgpack:"n"`
+	Description       string              `msgpack:"d,omitempty"`
+	Allowed           map[ObjectType]Tags `msgpack:"a,omitempty"` // Equipment of the given type must have at least one of the tags.
+	Forbidden         map[ObjectType]Tags `msgpack:"f,omitempty"` // Equipment of the given type must not have any of the tags.
+	Requires          map[string]Tags     `msgpack:"R,omitempty"` // Equipment with the given tag must also have at least one of the tags, such as boots needing to be made for wilders.
+	DamageMultipliers WeaponMultipliers   `msgpack:"D,omitempty"` // Multipliers to the damage of the given weapon types.
+	Attributes        Attributes          `msgpack:"A,omitempty"` // Modifiers to the character's attributes.
+	HungerModifier    float64             `msgpack:"h,omitempty"` // Fraction of additional energy used, such as -0.25 for 25% less.
+	RegenModifier     int                 `msgpack:"r,omitempty"` // Additional health regen.
 }
 
-// TraitList is a list of traits.
-type TraitList []Trait
+// String returns the name of the trait.
+func (t Trait) String() string {
+	return t.Name
+}
 
-// ToStrings converts a TraitList to a list of strings.
-func (tl *TraitList) ToStrings() []string {
-	var traits []string
-	for _, trait := range *tl {
-		traits = append(traits, trait.String())
+// CanApply returns true if the trait can be applied to the object.
+func (t Trait) CanApply(o Object) bool {
+	var tags Tags
+	switch o := o.(type) {
+	case *Weapon:
+		tags = o.Archetype.(WeaponArchetype).Tags
+	case *Armor:
+		tags = o.Archetype.(ArmorArchetype).Tags
+	default:
+		return true
 	}
-	return traits
+	if allowed, ok := t.Allowed[o.Type()]; ok && !tags.HasAny(allowed...) {
+		return false
+	}
+	if forbidden, ok := t.Forbidden[o.Type()]; ok && tags.HasAny(forbidden...) {
+		return false
+	}
+	for tag, required := range t.Requires {
+		if tags.Has(tag) && !tags.HasAny(required...) {
+			return false
+		}
+	}
+	return true
 }
 
-// UnmarshalJSON unmarshals a list of traits to their actual concrete types, if available.
-func (tl *TraitList) UnmarshalJSON(b []byte) error {
-	var traits []string
-	if err := json.Unmarshal(b, &traits); err != nil {
-		return err
-	}
-	for _, trait := range traits {
-		switch trait {
-		case TraitKungFu{}.String():
-			*tl = append(*tl, TraitKungFu{})
-		case TraitClubber{}.String():
-			*tl = append(*tl, TraitClubber{})
-		default:
-			fmt.Println("unknown trait:", trait)
+// AdjustDamages adjusts the provided damages.
+func (t Trait) AdjustDamages(damages []Damage) []Damage {
+	for i, damage := range damages {
+		if m, ok := t.DamageMultipliers[damage.Weapon]; ok {
+			damages[i].Min = int(float64(damage.Min) * m)
+			damages[i].Max = int(float64(damage.Max) * m)
+			damages[i].Extra = int(float64(damage.Extra) * m)
 		}
 	}
-	return nil
+	return damages
 }
 
-// MarshalMsgpack marshals a traitlist to a msgpack.
-func (tl TraitList) MarshalMsgpack() ([]byte, error) {
-	return msgpack.Marshal(tl.ToStrings())
+// AdjustEnergy adjusts the provided energy use.
+func (t Trait) AdjustEnergy(energy int) int {
+	return int(math.Round(float64(energy) * (1 + t.HungerModifier)))
 }
 
-// UnmarshalMsgpack unmarshals a traitlist.
-func (tl *TraitList) UnmarshalMsgpack(b []byte) error {
-	var traits []string
-	if err := msgpack.Unmarshal(b, &traits); err != nil {
+// AdjustRegen adjusts the provided health regen.
+func (t Trait) AdjustRegen(regen int) int {
+	return regen + t.RegenModifier
+}
+
+// WeaponMultipliers are multipliers keyed by weapon type.
+type WeaponMultipliers map[WeaponType]float64
+
+// UnmarshalJSON unmarshals a map of weapon type names to multipliers.
+func (wm *WeaponMultipliers) UnmarshalJSON(b []byte) error {
+	var m map[string]float64
+	if err := json.Unmarshal(b, &m); err != nil {
 		return err
 	}
-	for _, trait := range traits {
-		switch trait {
-		case TraitKungFu{}.String():
-			*tl = append(*tl, TraitKungFu{})
-		case TraitClubber{}.String():
-			*tl = append(*tl, TraitClubber{})
+	*wm = make(WeaponMultipliers)
+	for k, v := range m {
+		var w WeaponType
+		if err := w.UnmarshalJSON([]byte(`"` + k + `"`)); err != nil {
+			return err
 		}
+		(*wm)[w] = v
 	}
 	return nil
 }
 
-// TraitKungFu is the kung fu!
-type TraitKungFu struct {
-}
+// traits is our registry of traits by name.
+var traits = make(map[string]Trait)
 
-// String returns "kung fu".
-func (t TraitKungFu) String() string {
-	return "kung fu"
+// RegisterTrait registers the trait by its name, replacing any trait of the same name.
+func RegisterTrait(t Trait) {
+	traits[t.Name] = t
 }
 
-// CanApply returns true if the trait can be applied to the object.
-func (t TraitKungFu) CanApply(o Object) bool {
-	return true
+// TraitByName returns the registered trait with the given name.
+func TraitByName(name string) (Trait, error) {
+	if t, ok := traits[name]; ok {
+		return t, nil
+	}
+	return Trait{}, fmt.Errorf("%w: %s", ErrUnknownTrait, name)
 }
 
-// AdjustDamages adjusts the provided damages.
-func (t TraitKungFu) AdjustDamages(damages []Damage) []Damage {
-	for i, damage := range damages {
-		if damage.Weapon == WeaponTypeUnarmed {
-			damages[i].Min *= 3
-			damages[i].Max *= 3
-		}
+// DecodeTrait decodes a trait from JSON.
+func DecodeTrait(b []byte) (Trait, error) {
+	var t Trait
+	if err := json.Unmarshal(b, &t); err != nil {
+		return t, err
 	}
-	return damages
+	if t.Name == "" {
+		return t, ErrTraitMissingName
+	}
+	return t, nil
 }
 
-// TraitClubber is the clubber.
-type TraitClubber struct {
+// TraitList is a list of traits.
+type TraitList []Trait
+
+// ToStrings converts a TraitList to a list of strings.
+func (tl *TraitList) ToStrings() []string {
+	var traits []string
+	for _, trait := range *tl {
+		traits = append(traits, trait.String())
+	}
+	return traits
 }
 
-// String returns "only clubs".
-func (t TraitClubber) String() string {
-	return "only clubs"
+// Attributes returns the sum of the traits' attribute modifiers.
+func (tl TraitList) Attributes() (a Attributes) {
+	for _, t := range tl {
+		a.Swole += t.Attributes.Swole
+		a.Zooms += t.Attributes.Zooms
+		a.Brains += t.Attributes.Brains
+		a.Funk += t.Attributes.Funk
+	}
+	return
 }
 
-// CanApply returns true if the trait can be applied to the object.
-func (t TraitClubber) CanApply(o Object) bool {
-	switch o := o.(type) {
-	case *Weapon:
-		title := strings.ToLower(o.Archetype.(WeaponArchetype).Title)
-		// FIXME: Use a weapon kind system.
-		if !strings.Contains(title, "club") && !strings.Contains(title, "stick") && !strings.Contains(title, "cudgel") && !strings.Contains(title, "cane") {
-			return false
+// UnmarshalJSON unmarshals a list of trait names to their registered traits. As such, traits must be registered before any archetypes that use them are decoded.
+func (tl *TraitList) UnmarshalJSON(b []byte) error {
+	var names []string
+	if err := json.Unmarshal(b, &names); err != nil {
+		return err
+	}
+	var errs []error
+	for _, name := range names {
+		t, err := TraitByName(name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
 		}
-	default:
-		fmt.Println("unhandled", o)
+		*tl = append(*tl, t)
 	}
-	return true
+	return errors.Join(errs...)
 }
 
-// AdjustDamages adjusts the provided damages.
-func (t TraitClubber) AdjustDamages(damages []Damage) []Damage {
-	return damages
-}
+// Our trait errors.
+var (
+	ErrUnknownTrait     = errors.New(lc.T("unknown trait"))
+	ErrTraitMissingName = errors.New(lc.T("trait is missing a name"))
+)
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"math/rand"
+
+	"github.com/kettek/morogue/id"
+)
+
+// TrapTrigger is what sets off a trap.
+type TrapTrigger uint8
+
+// Our trap triggers.
+const (
+	TrapTriggerStep TrapTrigger = iota // Stepping onto the trap.
+	TrapTriggerNear                    // Stepping onto or next to the trap, such as a tripwire or pressure plate spanning the area.
+)
+
+// UnmarshalJSON unmarshals a string into our TrapTrigger.
+func (t *TrapTrigger) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case `"near"`:
+		*t = TrapTriggerNear
+	default:
+		*t = TrapTriggerStep
+	}
+	return nil
+}
+
+// Triggers returns true if a character at the given position sets off a trap of this trigger at the trap's position.
+func (t TrapTrigger) Triggers(trap, p Position) bool {
+	switch t {
+	case TrapTriggerNear:
+		return trap.X-p.X <= 1 && p.X-trap.X <= 1 && trap.Y-p.Y <= 1 && p.Y-trap.Y <= 1
+	default:
+		return trap == p
+	}
+}
+
+// TrapArchetype is the archetype for traps.
+type TrapArchetype struct {
+	ID          id.UUID
+	Title       string      `msgpack:"T,omitempty"`
+	Description string      `msgpack:"d,omitempty"`
+	Image       string      `msgpack:"i,omitempty"`
+	Trigger     TrapTrigger `msgpack:"-"`
+	MinDamage   int         `msgpack:"m,omitempty"`
+	MaxDamage   int         `msgpack:"M,omitempty"`
+	Effects     Statuses    `msgpack:"e,omitempty"` // Statuses inflicted on whoever sets off the trap.
+	Difficulty  int         `msgpack:"D,omitempty"` // How hard the trap is to find, avoid, and disarm.
+	Rearms      bool        `msgpack:"-"`           // Whether the trap can go off again. Otherwise it is spent once triggered.
+	Obvious     bool        `msgpack:"-"`           // Whether the trap starts out revealed.
+}
+
+// Type returns "trap".
+func (a TrapArchetype) Type() string {
+	return "trap"
+}
+
+// GetID returns the ID of the archetype.
+func (a TrapArchetype) GetID() id.UUID {
+	return a.ID
+}
+
+// RollDamage returns a roll of the trap's damage.
+func (a TrapArchetype) RollDamage() int {
+	if a.MaxDamage <= a.MinDamage {
+		return a.MinDamage
+	}
+	return a.MinDamage + rand.Intn(a.MaxDamage-a.MinDamage+1)
+}
+
+// Trap is a trap in the world. Traps are hidden from clients until revealed.
+type Trap struct {
+	Objectable
+	Position
+	Revealed bool `msgpack:"r,omitempty"`
+	Disarmed bool `msgpack:"D,omitempty"` // Disarmed or spent traps no longer go off.
+}
+
+// Type returns "trap".
+func (o Trap) Type() ObjectType {
+	return "trap"
+}
+
+// Armed returns true if the trap can still go off.
+func (o *Trap) Armed() bool {
+	return !o.Disarmed
+}
+
+// trapChance clamps a trap-related chance, as there is always some chance to succeed or fail.
+func trapChance(chance float64) float64 {
+	if chance < 0.05 {
+		return 0.05
+	} else if chance > 0.95 {
+		return 0.95
+	}
+	return chance
+}
+
+// TrapDetectChance returns the chance of the character finding a hidden trap of the given difficulty when searching.
+func TrapDetectChance(c *Character, difficulty int) float64 {
+	return trapChance(0.3 + float64(c.Brains())*0.05 - float64(difficulty)*0.1)
+}
+
+// TrapAvoidChance returns the chance of the character by-passing a revealed trap of the given difficulty rather than setting it off.
+func TrapAvoidChance(c *Character, difficulty int) float64 {
+	return trapChance(0.4 + float64(c.Brains())*0.05 - float64(difficulty)*0.1)
+}
+
+// TrapDisarmChance returns the chance of the character disarming a trap of the given difficulty.
+func TrapDisarmChance(c *Character, difficulty int) float64 {
+	return trapChance(0.2 + float64(c.Brains())*0.05 - float64(difficulty)*0.1)
+}
@@ -79,7 +79,12 @@ type WeaponArchetype struct {
 	WeaponType         WeaponType `msgpack:"w,omitempty"`
 	MinDamage          int        `msgpack:"m,omitempty"` // Character proficiency with a weapon increases min up to max.
 	MaxDamage          int        `msgpack:"M,omitempty"`
+	Range              int        `msgpack:"r,omitempty"` // Range in cells for ranged and thrown weapons.
 	Slots              Slots      `msgpack:"S,omitempty"`
+	Tags               Tags       `msgpack:"t,omitempty"` // Kinds the weapon is considered as, such as "club".
+	Effects            Statuses   `msgpack:"e,omitempty"` // Statuses inflicted on whatever the weapon hits.
+	Weight             int        `msgpack:"W,omitempty"` // Weight when carried.
+	Light              Light      `msgpack:"L,omitempty"` // Light emitted by the weapon when equipped or on the ground, such as from a torch.
 }
 
 // Type returns the type of the archetype.
@@ -92,6 +97,16 @@ func (a WeaponArchetype) GetID() id.UUID {
 	return a.ID
 }
 
+// GetLight returns the light emitted.
+func (a WeaponArchetype) GetLight() Light {
+	return a.Light
+}
+
+// GetWeight returns the weight.
+func (a WeaponArchetype) GetWeight() int {
+	return a.Weight
+}
+
 // RangeString returns the string representation of the damage range.
 func (a WeaponArchetype) RangeString() string {
 	if a.MinDamage == 0 {
@@ -3,9 +3,17 @@ package gen
 import "github.com/kettek/morogue/id"
 
 type Fixture struct {
-	ID   id.UUID
-	Keys map[string]id.UUID
-	Rows []string
+	ID    id.UUID
+	Keys  map[string]id.UUID
+	Locks map[string]Lock // Keys for lockable objects, such as doors and chests, that should be placed locked.
+	Floor id.UUID         // Tile placed beneath keys that are objects rather than tiles.
+	Rows  []string
+}
+
+// Lock is a lock to place on a fixture's object. A key fitting the lock is placed somewhere reachable outside of the fixture.
+type Lock struct {
+	ID         string
+	Difficulty int
 }
 
 func (f Fixture) Width() (w int) {
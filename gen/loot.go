@@ -0,0 +1,92 @@
+package gen
+
+import (
+	"math/rand"
+
+	"github.com/kettek/morogue/id"
+)
+
+// maxLootNesting is how deep loot tables may nest within each other, guarding against tables that include themselves.
+const maxLootNesting = 8
+
+// LootTable is a weighted table of objects to generate, such as treasure, chest contents, or mob drops. Tables of mob archetypes double as spawn tables.
+type LootTable struct {
+	ID      id.UUID
+	Rolls   MinMax // How many entries are picked. 0 means 1.
+	Entries []LootEntry
+}
+
+// LootEntry is a possible pick of a loot table. The ID may be an archetype or another loot table, while an entry with no ID yields nothing.
+type LootEntry struct {
+	ID     id.UUID
+	Weight int    // Relative chance of the entry being picked. 0 means 1.
+	Count  MinMax // How many of the entry are generated. 0 means 1.
+	Depth  MinMax // Depths the entry can be picked at. 0 for both means any depth.
+}
+
+// HasDepth returns true if the entry can be picked at the given depth.
+func (e LootEntry) HasDepth(depth int) bool {
+	if e.Depth.Min() == 0 && e.Depth.Max() == 0 {
+		return true
+	}
+	return depth >= e.Depth.Min() && depth <= e.Depth.Max()
+}
+
+func (e LootEntry) weight() int {
+	if e.Weight <= 0 {
+		return 1
+	}
+	return e.Weight
+}
+
+// Roll picks from the table at the given depth and returns the archetype IDs to generate. Nested tables are looked up with the given function and rolled in turn.
+func (t LootTable) Roll(depth int, lookup func(id.UUID) (LootTable, bool)) []id.UUID {
+	return t.roll(depth, lookup, 0)
+}
+
+func (t LootTable) roll(depth int, lookup func(id.UUID) (LootTable, bool), nesting int) (ids []id.UUID) {
+	if nesting > maxLootNesting {
+		return nil
+	}
+	var entries []LootEntry
+	total := 0
+	for _, e := range t.Entries {
+		if e.HasDepth(depth) {
+			entries = append(entries, e)
+			total += e.weight()
+		}
+	}
+	if total == 0 {
+		return nil
+	}
+	rolls := t.Rolls.Roll()
+	if rolls <= 0 {
+		rolls = 1
+	}
+	for i := 0; i < rolls; i++ {
+		pick := rand.Intn(total)
+		var entry LootEntry
+		for _, e := range entries {
+			pick -= e.weight()
+			if pick < 0 {
+				entry = e
+				break
+			}
+		}
+		if entry.ID.IsNil() {
+			continue
+		}
+		count := entry.Count.Roll()
+		if count <= 0 {
+			count = 1
+		}
+		for j := 0; j < count; j++ {
+			if nested, ok := lookup(entry.ID); ok {
+				ids = append(ids, nested.roll(depth, lookup, nesting+1)...)
+			} else {
+				ids = append(ids, entry.ID)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,91 @@
+package gen
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kettek/morogue/id"
+)
+
+func mustUID(t *testing.T, name string) id.UUID {
+	t.Helper()
+	u, err := id.UID(id.Item, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func TestLootEntryHasDepth(t *testing.T) {
+	tests := []struct {
+		name  string
+		depth MinMax
+		at    int
+		want  bool
+	}{
+		{"any depth", MinMax{0, 0}, 7, true},
+		{"below range", MinMax{2, 4}, 1, false},
+		{"at min", MinMax{2, 4}, 2, true},
+		{"at max", MinMax{2, 4}, 4, true},
+		{"above range", MinMax{2, 4}, 5, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (LootEntry{Depth: tt.depth}).HasDepth(tt.at); got != tt.want {
+				t.Errorf("HasDepth(%d) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLootTableRoll(t *testing.T) {
+	shallow := mustUID(t, "shallow")
+	deep := mustUID(t, "deep")
+	nested := mustUID(t, "nested")
+	looping := mustUID(t, "looping")
+
+	tables := map[id.UUID]LootTable{
+		nested: {
+			ID:      nested,
+			Entries: []LootEntry{{ID: deep, Count: MinMax{2, 2}}},
+		},
+		looping: {
+			ID:      looping,
+			Entries: []LootEntry{{ID: looping}},
+		},
+	}
+	lookup := func(u id.UUID) (LootTable, bool) {
+		table, ok := tables[u]
+		return table, ok
+	}
+	byDepth := LootTable{
+		Entries: []LootEntry{
+			{ID: shallow, Depth: MinMax{1, 2}},
+			{ID: deep, Depth: MinMax{3, 5}},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		table LootTable
+		depth int
+		want  []id.UUID
+	}{
+		{"shallow depth", byDepth, 1, []id.UUID{shallow}},
+		{"deep depth", byDepth, 4, []id.UUID{deep}},
+		{"no entries at depth", byDepth, 9, nil},
+		{"empty entry", LootTable{Entries: []LootEntry{{}}}, 1, nil},
+		{"rolls", LootTable{Rolls: MinMax{3, 3}, Entries: []LootEntry{{ID: shallow}}}, 1, []id.UUID{shallow, shallow, shallow}},
+		{"count", LootTable{Entries: []LootEntry{{ID: shallow, Count: MinMax{2, 2}}}}, 1, []id.UUID{shallow, shallow}},
+		{"nested table", LootTable{Entries: []LootEntry{{ID: nested}}}, 1, []id.UUID{deep, deep}},
+		{"nested table per count", LootTable{Entries: []LootEntry{{ID: nested, Count: MinMax{2, 2}}}}, 1, []id.UUID{deep, deep, deep, deep}},
+		{"self nesting table", tables[looping], 1, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.table.Roll(tt.depth, lookup); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Roll(%d) = %v, want %v", tt.depth, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package gen
+
+import "testing"
+
+func TestMinMaxRoll(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        MinMax
+		min, max int // Expected bounds of the rolls, inclusive.
+	}{
+		{"zero", MinMax{0, 0}, 0, 0},
+		{"fixed", MinMax{3, 3}, 3, 3},
+		{"range", MinMax{2, 5}, 2, 4},
+		{"negative", MinMax{-2, 1}, -2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if got := tt.m.Roll(); got < tt.min || got > tt.max {
+					t.Fatalf("Roll() = %d, want between %d and %d", got, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
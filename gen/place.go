@@ -5,12 +5,22 @@ import "github.com/kettek/morogue/id"
 type Place struct {
 	Title    string
 	ID       id.UUID
+	Depth    MinMax
 	Width    MinMax
 	Height   MinMax
+	Ambient  float64 // Ambient light level, from 0 for pitch black to 1 for broad daylight.
 	Fixtures []FixtureEntry
+	Stairs   []StairsEntry
+	Mobs     []MobEntry
+	Spawns   []SpawnEntry
 	WFC      []WFCEntry
 }
 
+// HasDepth returns true if the place can be generated at the given depth.
+func (p Place) HasDepth(depth int) bool {
+	return depth >= p.Depth.Min() && depth <= p.Depth.Max()
+}
+
 type FixtureEntry struct {
 	Targets []FixtureTarget
 	Count   MinMax
@@ -25,6 +35,26 @@ type FixtureTarget struct {
 	Intersect bool
 }
 
+// StairsEntry is a stairs or portal archetype to place within a generated place.
+type StairsEntry struct {
+	ID    id.UUID
+	Count MinMax
+}
+
+// MobEntry is a mob archetype to place within a generated place.
+type MobEntry struct {
+	ID    id.UUID
+	Count MinMax
+}
+
+// SpawnEntry is a rule for spawning mobs from a loot table throughout a generated place, both when it is generated and as time passes.
+type SpawnEntry struct {
+	Table    id.UUID
+	Density  float64 // Mobs spawned per 100 open cells when the place is generated.
+	Interval int     // Turns between respawns. 0 means mobs are never respawned.
+	MaxAlive int     // Most mobs from the rule alive at once. 0 means as many as the density calls for.
+}
+
 type WFCEntry struct {
 	ID       id.UUID
 	Adjacent []id.UUID
@@ -16,6 +16,9 @@ const (
 	KeyArmor     = "morogue:armor"
 	KeyFood      = "morogue:food"
 	KeyBag       = "morogue:bag"
+	KeyStairs    = "morogue:stairs"
+	KeyKey       = "morogue:key"
+	KeyTrap      = "morogue:trap"
 	//
 	KeyPlace   = "morogue:place"
 	KeyFixture = "morogue:fixture"
@@ -31,6 +34,9 @@ var (
 	Armor     UUID
 	Food      UUID
 	Bag       UUID
+	Stairs    UUID
+	Key       UUID
+	Trap      UUID
 	//
 	Place   UUID
 	Fixture UUID
@@ -126,6 +132,33 @@ func init() {
 		NamespaceToKey[Bag] = KeyBag
 		KeyToNamespace[KeyBag] = Bag
 	}
+	{
+		hasher := sha1.New()
+		hasher.Write([]byte(KeyStairs))
+		sha := hasher.Sum(nil)
+
+		Stairs = UUID(uuid.Must(uuid.FromBytes(sha[:16])))
+		NamespaceToKey[Stairs] = KeyStairs
+		KeyToNamespace[KeyStairs] = Stairs
+	}
+	{
+		hasher := sha1.New()
+		hasher.Write([]byte(KeyKey))
+		sha := hasher.Sum(nil)
+
+		Key = UUID(uuid.Must(uuid.FromBytes(sha[:16])))
+		NamespaceToKey[Key] = KeyKey
+		KeyToNamespace[KeyKey] = Key
+	}
+	{
+		hasher := sha1.New()
+		hasher.Write([]byte(KeyTrap))
+		sha := hasher.Sum(nil)
+
+		Trap = UUID(uuid.Must(uuid.FromBytes(sha[:16])))
+		NamespaceToKey[Trap] = KeyTrap
+		KeyToNamespace[KeyTrap] = Trap
+	}
 	//
 	{
 		hasher := sha1.New()
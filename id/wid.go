@@ -18,3 +18,8 @@ func (w *WIDGenerator) Next() WID {
 func (w *WIDGenerator) Top() WID {
 	return w.top
 }
+
+// SetTop sets the "top" WID, so that generation continues after it. This is used when restoring a world.
+func (w *WIDGenerator) SetTop(top WID) {
+	w.top = top
+}
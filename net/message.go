@@ -73,6 +73,10 @@ func (w *Wrapper) Message() Message {
 		var m LocationMessage
 		msgpack.Unmarshal(w.Data, &m)
 		return m
+	case (CellsMessage{}).Type():
+		var m CellsMessage
+		msgpack.Unmarshal(w.Data, &m)
+		return m
 	case (DesireMessage{}).Type():
 		var m DesireMessage
 		msgpack.Unmarshal(w.Data, &m)
@@ -227,6 +231,12 @@ func (m *ArchetypesMessage) UnmarshalMsgpack(data []byte) error {
 				panic(err)
 			}
 			m.Archetypes = append(m.Archetypes, archetype)
+		case (game.StairsArchetype{}).Type():
+			var archetype game.StairsArchetype
+			if err := msgpack.Unmarshal(a.Data, &archetype); err != nil {
+				panic(err)
+			}
+			m.Archetypes = append(m.Archetypes, archetype)
 		}
 	}
 
@@ -343,12 +353,29 @@ type LocationMessage struct {
 	ID         id.UUID      `msgpack:"id,omitempty"`
 	Objects    game.Objects `msgpack:"o,omitempty"`
 	Cells      game.Cells   `msgpack:"g,omitempty"`
+	Ambient    float64      `msgpack:"a,omitempty"`
 }
 
 func (m LocationMessage) Type() string {
 	return "location"
 }
 
+// CellsMessage updates cells of the given location that have come into view.
+type CellsMessage struct {
+	ID    id.UUID `msgpack:"id,omitempty"`
+	Cells []Cell  `msgpack:"g,omitempty"`
+}
+
+func (m CellsMessage) Type() string {
+	return "cells"
+}
+
+// Cell is a cell along with its position.
+type Cell struct {
+	game.Position `msgpack:"p,omitempty"`
+	Cell          game.Cell `msgpack:"c,omitempty"`
+}
+
 type OwnerMessage struct {
 	WID        id.WID          `msgpack:"wid,omitempty"`
 	Inventory  game.Objects    `msgpack:"i,omitempty"`
@@ -1,13 +1,25 @@
 package server
 
-import "github.com/kettek/morogue/game"
+import (
+	"github.com/kettek/morogue/game"
+	"github.com/kettek/morogue/id"
+)
 
 // Hurtable is the interface for objects that can be hurt.
 type Hurtable interface {
 	CalculateFromObject(o game.Object)
+	ResolveDamages(damages []game.DamageResult) []game.DamageResult
 	TakeDamages(damages []game.DamageResult)
-	TakeHeal(heal int)
+	TakeHeal(heal int) bool
 	IsDead() bool
+	AddStatus(s game.Status) (game.Status, bool)
+	TickStatuses() (ticked game.Statuses, expired game.Statuses)
+}
+
+// Breakable is the interface for objects that break when out of health, such as doors and chests.
+type Breakable interface {
+	CanBreak() bool
+	IsBroken() bool
 }
 
 // Appliable is the interface for objects that can be applied.
@@ -40,6 +52,9 @@ type Lockable interface {
 	Lock()
 	Unlock()
 	IsLocked() bool
+	GetLockID() string
+	GetDifficulty() int
+	SetLock(lockID string, difficulty int)
 }
 
 // Edible is the interface for objects that can be eaten.
@@ -53,3 +68,11 @@ type Containerable interface {
 	Pickup(o game.Object) bool
 	Drop(o game.Object) bool
 }
+
+// Linkable is the interface for objects that link to other locations.
+type Linkable interface {
+	IsLinked() bool
+	Link(location id.UUID, wid id.WID)
+	Unlink()
+	Linked() (id.UUID, id.WID)
+}
@@ -22,14 +22,9 @@ type accounts struct {
 	db *bolt.DB
 }
 
-// NewAccounts creates a new accounts database with the given path.
-func NewAccounts(path string) (Accounts, error) {
-	db, err := bolt.Open(path, 0666, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	err = db.Update(func(tx *bolt.Tx) error {
+// NewAccounts creates a new accounts bucket within the given database.
+func NewAccounts(db *bolt.DB) (Accounts, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
 		_, err := tx.CreateBucketIfNotExists([]byte("accounts"))
 		return err
 	})
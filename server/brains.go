@@ -0,0 +1,148 @@
+package server
+
+import (
+	"math/rand"
+
+	"github.com/kettek/morogue/game"
+)
+
+// Brain decides what a non-player character desires to do. Think is called whenever the character is able to act and should return nil if the brain has nothing it wishes to do.
+type Brain interface {
+	Think(l *location, c *game.Character) game.Desire
+}
+
+// brains is our registry of brains by the name used in a CharacterArchetype's Brain.
+var brains = map[string]func() Brain{
+	"idle": func() Brain {
+		return &idleBrain{}
+	},
+	"wander": func() Brain {
+		return &wanderBrain{}
+	},
+	"coward": func() Brain {
+		return brainChain{
+			&fleeBrain{Sight: 8, Health: 1},
+			&wanderBrain{},
+		}
+	},
+	"brute": func() Brain {
+		return brainChain{
+			&attackBrain{},
+			&chaseBrain{Sight: 8},
+			&wanderBrain{},
+		}
+	},
+	"hunter": func() Brain {
+		return brainChain{
+			&fleeBrain{Sight: 8, Health: 0.25},
+			&attackBrain{},
+			&chaseBrain{Sight: 8},
+			&wanderBrain{},
+		}
+	},
+}
+
+// newBrain returns a new brain for the given name. If the name is not registered, the wander brain is returned.
+func newBrain(name string) Brain {
+	if fn, ok := brains[name]; ok {
+		return fn()
+	}
+	return brains["wander"]()
+}
+
+// brainChain is a brain that asks each of its brains in order, returning the first desire.
+type brainChain []Brain
+
+// Think returns the first desire of the chain's brains.
+func (b brainChain) Think(l *location, c *game.Character) game.Desire {
+	for _, brain := range b {
+		if d := brain.Think(l, c); d != nil {
+			return d
+		}
+	}
+	return nil
+}
+
+// idleBrain never desires anything.
+type idleBrain struct{}
+
+// Think returns nil.
+func (b *idleBrain) Think(l *location, c *game.Character) game.Desire {
+	return nil
+}
+
+// wanderBrain wanders aimlessly, occasionally keeping to a direction for a few steps.
+type wanderBrain struct {
+	direction game.MoveDirection
+	steps     int
+}
+
+// Think returns a random move desire or nil if the character is resting.
+func (b *wanderBrain) Think(l *location, c *game.Character) game.Desire {
+	if b.steps <= 0 {
+		// Rest a bit between strolls.
+		if rand.Intn(3) == 0 {
+			return nil
+		}
+		b.direction = game.MoveDirectionFromPosition(rand.Intn(3)-1, rand.Intn(3)-1)
+		b.steps = 1 + rand.Intn(4)
+	}
+	b.steps--
+	if b.direction == game.CenterMoveDirection {
+		return nil
+	}
+	return game.DesireMove{
+		Direction: b.direction,
+	}
+}
+
+// chaseBrain moves towards the nearest player character within sight.
+type chaseBrain struct {
+	Sight int // Distance at which a player can be noticed.
+}
+
+// Think returns a move desire towards the nearest player in sight.
+func (b *chaseBrain) Think(l *location, c *game.Character) game.Desire {
+	target, distance := l.noticedPlayerCharacter(c)
+	if target == nil || distance > b.Sight || distance <= 1 {
+		return nil
+	}
+	return game.DesireMove{
+		Direction: c.Position.DirectionTo(target.Position),
+	}
+}
+
+// fleeBrain moves away from the nearest player character within sight when the character's health is low.
+type fleeBrain struct {
+	Sight  int     // Distance at which a player can be noticed.
+	Health float64 // Fraction of max health at or below which to flee.
+}
+
+// Think returns a move desire away from the nearest player in sight.
+func (b *fleeBrain) Think(l *location, c *game.Character) game.Desire {
+	if c.MaxHealth > 0 && float64(c.Health)/float64(c.MaxHealth) > b.Health {
+		return nil
+	}
+	target, distance := l.noticedPlayerCharacter(c)
+	if target == nil || distance > b.Sight {
+		return nil
+	}
+	return game.DesireMove{
+		Direction: target.Position.DirectionTo(c.Position),
+	}
+}
+
+// attackBrain attacks an adjacent player character.
+type attackBrain struct{}
+
+// Think returns a bash desire against an adjacent player.
+func (b *attackBrain) Think(l *location, c *game.Character) game.Desire {
+	target, distance := l.noticedPlayerCharacter(c)
+	if target == nil || distance != 1 {
+		return nil
+	}
+	return game.DesireBash{
+		Direction: c.Position.DirectionTo(target.Position),
+		WID:       target.WID,
+	}
+}
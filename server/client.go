@@ -27,4 +27,5 @@ type client struct {
 	msgChan          chan net.Message
 	closedChan       chan error
 	lastWorldsSent   time.Time
+	vision           vision // What the client's character can see in their current location.
 }
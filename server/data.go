@@ -24,7 +24,17 @@ func (p Places) ByID(uid id.UUID) (gen.Place, error) {
 			return place, nil
 		}
 	}
-	return gen.Place{}, errors.New("no such place")
+	return gen.Place{}, ErrNoSuchPlace
+}
+
+// ByDepth returns all places that can be generated at the given depth.
+func (p Places) ByDepth(depth int) (places []gen.Place) {
+	for _, place := range p {
+		if place.HasDepth(depth) {
+			places = append(places, place)
+		}
+	}
+	return
 }
 
 // Fixtures is a slice of our generate-able fixtures.
@@ -40,11 +50,26 @@ func (f Fixtures) ByID(uid id.UUID) (gen.Fixture, error) {
 	return gen.Fixture{}, ErrNoSuchFixture
 }
 
-// Data contains our archetypes, places, and fixtures.
+// LootTables is a slice of our loot tables.
+type LootTables []gen.LootTable
+
+// ByID returns a loot table by its UUID.
+func (t LootTables) ByID(uid id.UUID) (gen.LootTable, error) {
+	for _, table := range t {
+		if table.ID == uid {
+			return table, nil
+		}
+	}
+	return gen.LootTable{}, ErrNoSuchLootTable
+}
+
+// Data contains our traits, archetypes, places, fixtures, and loot tables.
 type Data struct {
+	Traits     []game.Trait
 	Archetypes []game.Archetype
 	Places     Places
 	Fixtures   Fixtures
+	LootTables LootTables
 }
 
 func (d *Data) hasArchetype(uuid id.UUID) bool {
@@ -66,6 +91,18 @@ func (d *Data) Archetype(uuid id.UUID) game.Archetype {
 	return nil
 }
 
+// isFixtureObject returns true if a fixture key with the given ID places objects rather than a tile.
+func (d *Data) isFixtureObject(uid id.UUID) bool {
+	if _, err := d.LootTables.ByID(uid); err == nil {
+		return true
+	}
+	switch d.Archetype(uid).(type) {
+	case game.DoorArchetype, game.BagArchetype, game.KeyArchetype, game.TrapArchetype:
+		return true
+	}
+	return false
+}
+
 // Tile returns a TileArchetype by its UUID.
 func (d *Data) Tile(uuid id.UUID) (game.TileArchetype, error) {
 	for _, t := range d.TileArchetypes() {
@@ -153,6 +190,67 @@ func (d *Data) BagArchetypes() []game.BagArchetype {
 	return archetypes
 }
 
+// StairsArchetypes returns a slice of all StairsArchetypes.
+func (d *Data) StairsArchetypes() []game.StairsArchetype {
+	var archetypes []game.StairsArchetype
+	for _, a := range d.Archetypes {
+		if s, ok := a.(game.StairsArchetype); ok {
+			archetypes = append(archetypes, s)
+		}
+	}
+	return archetypes
+}
+
+// KeyArchetypes returns a slice of all KeyArchetypes.
+func (d *Data) KeyArchetypes() []game.KeyArchetype {
+	var archetypes []game.KeyArchetype
+	for _, a := range d.Archetypes {
+		if k, ok := a.(game.KeyArchetype); ok {
+			archetypes = append(archetypes, k)
+		}
+	}
+	return archetypes
+}
+
+// LoadTraits loads and registers all traits from the traits directory. This must be called before LoadArchetypes.
+func (d *Data) LoadTraits() error {
+	var iterate func(string) error
+
+	iterate = func(fulldir string) error {
+		entries, err := os.ReadDir(fulldir)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				if err := iterate(filepath.Join(fulldir, entry.Name())); err != nil {
+					log.Println(err)
+				}
+			} else {
+				fullpath := filepath.Join(fulldir, entry.Name())
+				if strings.HasSuffix(entry.Name(), ".json") {
+					bytes, err := os.ReadFile(fullpath)
+					if err != nil {
+						log.Println(err)
+						continue
+					}
+					if t, err := game.DecodeTrait(bytes); err != nil {
+						log.Println(errors.Join(fmt.Errorf("failed to decode trait %s", fullpath), err))
+					} else {
+						game.RegisterTrait(t)
+						d.Traits = append(d.Traits, t)
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	iterate("traits")
+
+	return nil
+}
+
 // LoadArchetypes loads all archetypes from the archetypes directory.
 func (d *Data) LoadArchetypes() error {
 	var iterate func(string, string) error
@@ -269,8 +367,61 @@ func (d *Data) LoadFixtures() error {
 	return nil
 }
 
+// LoadLootTables loads all loot tables from the loot directory.
+func (d *Data) LoadLootTables() error {
+	var iterate func(string, string) error
+
+	iterate = func(fulldir string, partialdir string) error {
+		entries, err := os.ReadDir(fulldir)
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				if err := iterate(filepath.Join(fulldir, entry.Name()), filepath.Join(partialdir, entry.Name())); err != nil {
+					log.Println(err)
+				}
+			} else {
+				fullpath := filepath.Join(fulldir, entry.Name())
+				if strings.HasSuffix(entry.Name(), ".json") {
+					bytes, err := os.ReadFile(fullpath)
+					if err != nil {
+						log.Println(err)
+						continue
+					}
+					var t gen.LootTable
+					if err := json.Unmarshal(bytes, &t); err != nil {
+						log.Println(errors.Join(fmt.Errorf("failed to decode loot table %s", fullpath), err))
+					} else {
+						d.LootTables = append(d.LootTables, t)
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	iterate("loot", "")
+
+	return nil
+}
+
+// RollLoot rolls the loot table with the given ID at the given depth, returning the archetype IDs to generate.
+func (d *Data) RollLoot(table id.UUID, depth int) []id.UUID {
+	t, err := d.LootTables.ByID(table)
+	if err != nil {
+		return nil
+	}
+	return t.Roll(depth, func(uid id.UUID) (gen.LootTable, bool) {
+		t, err := d.LootTables.ByID(uid)
+		return t, err == nil
+	})
+}
+
 // Error types, yo.
 var (
-	ErrNoSuchFixture = errors.New(lc.T("no such fixture"))
-	ErrNoSuchTile    = errors.New(lc.T("no such tile"))
+	ErrNoSuchPlace     = errors.New(lc.T("no such place"))
+	ErrNoSuchFixture   = errors.New(lc.T("no such fixture"))
+	ErrNoSuchTile      = errors.New(lc.T("no such tile"))
+	ErrNoSuchLootTable = errors.New(lc.T("no such loot table"))
 )
@@ -0,0 +1,10 @@
+package server
+
+import (
+	bolt "go.etcd.io/bbolt"
+)
+
+// OpenDatabase opens the bbolt database at the given path. The returned database is shared by Accounts and Snapshots.
+func OpenDatabase(path string) (*bolt.DB, error) {
+	return bolt.Open(path, 0666, nil)
+}
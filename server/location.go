@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"slices"
 	"time"
 
 	"github.com/kettek/morogue/game"
@@ -15,7 +16,10 @@ import (
 
 type location struct {
 	game.Location
+	depth              int
+	travels            []travel          // Characters that wish to travel to another location. These are handled by the world.
 	playerCharacters   []*game.Character // List of active player characters
+	brains             map[id.WID]Brain  // Brains of non-player characters. These are created as needed.
 	active             bool
 	removable          bool // destroyable is used to allow a location to be removed.
 	emptySince         time.Time
@@ -24,19 +28,41 @@ type location struct {
 	turnActionLatch    int  // Trigger for processing a complete turn.
 	turnActionOOCLatch int  // The latch for actions out of combat. This is generally equal to a second or 20 calls to process.
 	inTurns            bool // Whether or not the location is currently processing the world in turns.
+	combatActivity     bool // Whether or not hostilities have occurred since combat was last checked.
+	quietTurns         int  // Turns that have passed without hostilities.
+	data               *Data
+	wids               *id.WIDGenerator   // The world's WID generator, for spawning objects after generation.
+	lighting           game.Lighting      // Illumination of the cells as of the last update.
+	lightSources       []game.LightSource // Lights shining from objects as of the last lighting update.
+	tileLights         []game.LightSource // Lights shining from lit tiles. These only change along with the tiles.
+	tileAmbient        [][]float64        // Ambient light of each cell from its tile.
+	lightingStale      bool               // Whether the lighting must be recomputed even if no lights changed, such as when a door opens.
+	spawners           []*spawner         // Spawn rules of the place the location was generated from.
 }
 
 func newLocation() *location {
 	return &location{
 		turnActionOOCLatch: 20,
+		brains:             make(map[id.WID]Brain),
 	}
 }
 
 func (l *location) addObject(o game.Object) {
 	l.Objects = append(l.Objects, o)
+	// A bag's contents are location objects as well, much like a character's inventory.
+	if b, ok := o.(*game.Bag); ok {
+		for _, o2 := range b.Inventory {
+			l.addObject(o2)
+		}
+	}
 }
 
 func (l *location) removeObject(o game.Object) {
+	if b, ok := o.(*game.Bag); ok {
+		for _, o2 := range b.Inventory {
+			l.removeObject(o2)
+		}
+	}
 	for i, o2 := range l.Objects {
 		if o2.GetWID() == o.GetWID() {
 			l.Objects = append(l.Objects[:i], l.Objects[i+1:]...)
@@ -57,11 +83,28 @@ func (l *location) addCharacter(character *game.Character) error {
 	if len(openCells) == 0 {
 		return ErrCharacterCannotPlaceInLocation
 	}
-	// TODO: We want to also handle non-random spawning, such as through stairs, etc.
+	spawnCell := openCells[rand.Intn(len(openCells))]
+
+	return l.addCharacterAt(character, game.Position{X: spawnCell.X, Y: spawnCell.Y})
+}
+
+// addCharacterAt adds the character to the location at the given position. This is used for arriving via stairs.
+func (l *location) addCharacterAt(character *game.Character, position game.Position) error {
+	if l.Character(character.WID) != nil {
+		return ErrCharacterAlreadyInLocation
+	}
+	if _, err := l.Cells.At(position.X, position.Y); err != nil {
+		return ErrCharacterCannotPlaceInLocation
+	}
+	// Don't stack arrivals on top of whoever is already there, such as on busy stairs.
+	if l.characterAt(position) != nil {
+		if p, ok := l.openCellNear(position); ok {
+			position = p
+		}
+	}
+
 	// Add to location.
-	spawnCell := openCells[rand.Intn(len(openCells)-1)]
-	character.X = spawnCell.X
-	character.Y = spawnCell.Y
+	character.SetPosition(position)
 
 	l.addObject(character)
 
@@ -91,11 +134,6 @@ func (l *location) removeCharacter(wid id.WID) error {
 				l.removeObject(o)
 			}
 
-			if len(l.Characters()) == 0 {
-				l.active = false
-				l.emptySince = time.Now()
-			}
-
 			// Remove the character from the list of player characters.
 			for i, c := range l.playerCharacters {
 				if c.WID == wid {
@@ -104,6 +142,11 @@ func (l *location) removeCharacter(wid id.WID) error {
 				}
 			}
 
+			if len(l.playerCharacters) == 0 {
+				l.active = false
+				l.emptySince = time.Now()
+			}
+
 			// Decreate the turn latch.
 			l.turnActionLatch--
 
@@ -122,12 +165,20 @@ func (l *location) moveCharacter(wid id.WID, dir game.MoveDirection) (events []g
 	x += ch.X
 	y += ch.Y
 
+	// Characters only walk, for now.
 	if cell, err := l.Cells.At(x, y); err != nil {
 		return nil, err
-	} else if cell.Blocks == game.MovementAll {
+	} else if cell.Blocks == game.MovementAll || cell.Blocks == game.MovementWalk {
 		return nil, ErrMovementBlocked
 	}
 
+	// Characters can't walk through each other.
+	for _, c := range l.Characters() {
+		if c.X == x && c.Y == y {
+			return nil, ErrMovementBlocked
+		}
+	}
+
 	ch.X = x
 	ch.Y = y
 
@@ -135,48 +186,110 @@ func (l *location) moveCharacter(wid id.WID, dir game.MoveDirection) (events []g
 		WID:      ch.WID,
 		Position: ch.Position,
 	})
+	events = append(events, l.gainExperience(ch, game.AttributeZooms, game.ExperienceMove)...)
+	events = append(events, l.enterTile(ch)...)
+	events = append(events, l.springTraps(ch)...)
 
 	// FIXME: This isn't the right place for this. There should be some sort of "actions" economy that is used to increase hunger.
 	ch.Movable.MoveCounter++
 	if ch.Movable.MoveCounter > 10 { // I guess 10 steps are reasonable enough for energy checks.
 		ch.Movable.MoveCounter = 0
-		energy := 1 + int(ch.Attributes.Swole/2) - int(ch.Attributes.Zooms/4)
-		if energy < 1 {
-			energy = 1
-		}
-		if ch.UseEnergy(energy) {
-			events = append(events, game.EventHunger{
-				WID:    ch.WID,
-				Hunger: ch.Hungerable.Hunger,
-			})
-			regen := ch.HealthRegen
-			// Might as well have a 1% chance to double heal per Funk point.
-			if rand.Float64() < float64(ch.Attributes.Funk)/100 {
-				regen *= 2
-			}
-			if ch.TakeHeal(regen) {
-				events = append(events, game.EventHealth{
-					Target: ch.WID,
-					Health: ch.Hurtable.Health,
-				})
-			}
+		// Only characters with a stomach get hungry.
+		if ch.MaxHunger > 0 {
+			events = append(events, l.useEnergy(ch)...)
 		}
 	}
 
 	return events, nil
 }
 
+// useEnergy uses a character's energy, applying the effects of their resulting hunger state and regenerating health if they are fed enough.
+func (l *location) useEnergy(ch *game.Character) (events []game.Event) {
+	energy := 1 + int(ch.Attributes.Swole/2) - int(ch.Attributes.Zooms/4)
+	for _, t := range ch.Archetype.(game.CharacterArchetype).Traits {
+		energy = t.AdjustEnergy(energy)
+	}
+	energy = ch.Encumbrance.AdjustEnergy(energy)
+	if energy < 1 {
+		energy = 1
+	}
+	ch.UseEnergy(energy)
+	events = append(events, game.EventHunger{
+		WID:    ch.WID,
+		Hunger: ch.Hungerable.Hunger,
+	})
+	events = append(events, l.updateHungerState(ch)...)
+
+	switch ch.HungerState {
+	case game.HungerStateStarving:
+		ch.TakeDamages([]game.DamageResult{{Damage: starvationDamage}})
+		events = append(events, game.EventHealth{
+			Target: ch.WID,
+			Health: ch.Hurtable.Health,
+		})
+		ch.Events = append(ch.Events, game.EventNotice{
+			Message: lc.T("You are starving!"),
+		})
+	case game.HungerStateFainting:
+		if rand.Float64() < faintChance {
+			ch.SpentActions = ch.Actions
+			ch.Events = append(ch.Events, game.EventNotice{
+				Message: lc.T("You faint from hunger."),
+			})
+		}
+	}
+
+	regen := ch.HungerState.AdjustRegen(ch.HealthRegen)
+	if regen <= 0 {
+		return events
+	}
+	// Might as well have a 1% chance to double heal per Funk point.
+	if rand.Float64() < float64(ch.Attributes.Funk)/100 {
+		regen *= 2
+	}
+	if ch.TakeHeal(regen) {
+		events = append(events, game.EventHealth{
+			Target: ch.WID,
+			Health: ch.Hurtable.Health,
+		})
+	}
+	return events
+}
+
+// updateHungerState updates the character's hunger state, returning an event if it has changed.
+func (l *location) updateHungerState(ch *game.Character) []game.Event {
+	if !ch.UpdateHungerState() {
+		return nil
+	}
+	ch.Movable.CalculateFromCharacter(ch)
+	return []game.Event{
+		game.EventHungerState{
+			WID:   ch.WID,
+			State: ch.HungerState,
+		},
+	}
+}
+
+// updateEncumbrance updates the character's encumbrance, returning an event if it has changed.
+func (l *location) updateEncumbrance(ch *game.Character) []game.Event {
+	if !ch.UpdateEncumbrance() {
+		return nil
+	}
+	ch.Movable.CalculateFromCharacter(ch)
+	return []game.Event{
+		game.EventEncumbrance{
+			WID:         ch.WID,
+			Encumbrance: ch.Encumbrance,
+		},
+	}
+}
+
 type wfcTile struct {
 	ID     id.UUID
 	Domain []id.UUID
 }
 
-func (l *location) generate(pid id.UUID, data *Data, wids *id.WIDGenerator) error {
-	place, err := data.Places.ByID(pid)
-	if err != nil {
-		return err
-	}
-
+func (l *location) generate(place gen.Place, data *Data, wids *id.WIDGenerator) error {
 	allPossibleTiles := []id.UUID{}
 
 	for _, w := range place.WFC {
@@ -185,6 +298,7 @@ func (l *location) generate(pid id.UUID, data *Data, wids *id.WIDGenerator) erro
 
 	w := place.Width.Roll()
 	h := place.Height.Roll()
+	l.Ambient = place.Ambient
 
 	var wfcTiles [][]wfcTile
 
@@ -230,6 +344,9 @@ func (l *location) generate(pid id.UUID, data *Data, wids *id.WIDGenerator) erro
 		}
 	}
 
+	// Fixture keys for objects, such as doors and chests, are placed once the tiles are resolved.
+	var fixtureObjects []fixtureObject
+
 	placeFixture := func(f gen.Fixture, px, py int) error {
 		w := f.Width()
 		h := f.Height()
@@ -241,6 +358,23 @@ func (l *location) generate(pid id.UUID, data *Data, wids *id.WIDGenerator) erro
 		for y, r := range f.Rows {
 			for x, c := range r {
 				if cid, ok := f.Keys[string(c)]; ok {
+					if data.isFixtureObject(cid) {
+						fo := fixtureObject{
+							ID:       cid,
+							Position: game.Position{X: px + x, Y: py + y},
+							Min:      game.Position{X: px, Y: py},
+							Max:      game.Position{X: px + w, Y: py + h},
+						}
+						if lock, ok := f.Locks[string(c)]; ok {
+							fo.Lock = &lock
+						}
+						fixtureObjects = append(fixtureObjects, fo)
+						// Leave the tile to WFC if the fixture has no floor to put beneath.
+						if f.Floor.IsNil() {
+							continue
+						}
+						cid = f.Floor
+					}
 					l.Cells[px+x][py+y].TileID = &cid
 					// Mark the tile as done for WFC purposes.
 					wfcTiles[px+x][py+y].ID = cid
@@ -297,9 +431,364 @@ func (l *location) generate(pid id.UUID, data *Data, wids *id.WIDGenerator) erro
 		l.Cells[x][y].TileID = &wfcTiles[x][y].ID
 	}
 
+	// Place our fixtures' objects, locking any that should be and filling any bags with loot.
+	var locked, loot []fixtureObject
+	for _, fo := range fixtureObjects {
+		if _, err := data.LootTables.ByID(fo.ID); err == nil {
+			loot = append(loot, fo)
+			continue
+		}
+		o := game.CreateObjectFromArchetype(data.Archetype(fo.ID))
+		o.SetWID(wids.Next())
+		o.SetPosition(fo.Position)
+		if lockable, ok := o.(Lockable); ok && fo.Lock != nil {
+			lockable.SetLock(fo.Lock.ID, fo.Lock.Difficulty)
+			locked = append(locked, fo)
+		}
+		l.addObject(o)
+		if bag, ok := o.(*game.Bag); ok {
+			l.fillBag(bag)
+		}
+	}
+	l.updateAllBlocks()
+
+	// Roll our fixtures' loot tables, now that the cells know what blocks them.
+	for _, fo := range loot {
+		l.spawnLoot(fo.ID, fo.Position)
+	}
+
+	// Place our stairs and portals.
+	for _, s := range place.Stairs {
+		a, ok := data.Archetype(s.ID).(game.StairsArchetype)
+		if !ok {
+			return fmt.Errorf("could not place stairs %s: %w", s.ID, ErrNoSuchArchetype)
+		}
+		count := s.Count.Roll()
+		for i := 0; i < count; i++ {
+			openCells := l.filterCells(func(c game.Cell) bool {
+				return c.Blocks == game.MovementNone
+			})
+			if len(openCells) == 0 {
+				return fmt.Errorf("could not place stairs %s: %w", s.ID, ErrCharacterCannotPlaceInLocation)
+			}
+			cell := openCells[rand.Intn(len(openCells))]
+			o := game.CreateObjectFromArchetype(a)
+			o.SetWID(wids.Next())
+			o.SetPosition(game.Position{X: cell.X, Y: cell.Y})
+			l.addObject(o)
+		}
+	}
+
+	// Place our mobs.
+	for _, m := range place.Mobs {
+		a, ok := data.Archetype(m.ID).(game.CharacterArchetype)
+		if !ok {
+			return fmt.Errorf("could not place mob %s: %w", m.ID, ErrNoSuchArchetype)
+		}
+		count := m.Count.Roll()
+		for i := 0; i < count; i++ {
+			openCells := l.filterCells(func(c game.Cell) bool {
+				return c.Blocks == game.MovementNone
+			})
+			if len(openCells) == 0 {
+				return fmt.Errorf("could not place mob %s: %w", m.ID, ErrCharacterCannotPlaceInLocation)
+			}
+			cell := openCells[rand.Intn(len(openCells))]
+			l.spawnMob(a, game.Position{X: cell.X, Y: cell.Y}, wids)
+		}
+	}
+
+	// Spawn the place's ambient mobs.
+	l.populate(place.Spawns)
+
+	// Place a key for each lock, now that the stairs it must be reachable from are placed.
+	keyed := make(map[string]bool)
+	for _, fo := range locked {
+		if keyed[fo.Lock.ID] {
+			continue
+		}
+		if err := l.placeKey(fo, data, wids); err != nil {
+			return err
+		}
+		keyed[fo.Lock.ID] = true
+	}
+
+	return nil
+}
+
+// placeKey places a key that fits the fixture object's lock somewhere reachable outside of the object's fixture.
+func (l *location) placeKey(fo fixtureObject, data *Data, wids *id.WIDGenerator) error {
+	var key *game.KeyArchetype
+	for _, a := range data.KeyArchetypes() {
+		if a.Fits(fo.Lock.ID) {
+			key = &a
+			break
+		}
+	}
+	if key == nil {
+		return fmt.Errorf("could not place key for lock %s: %w", fo.Lock.ID, ErrNoSuchArchetype)
+	}
+
+	var cells []cellLocation
+	for _, c := range l.reachableCells() {
+		if !fo.Contains(c.X, c.Y) {
+			cells = append(cells, c)
+		}
+	}
+	if len(cells) == 0 {
+		return fmt.Errorf("could not place key for lock %s: %w", fo.Lock.ID, ErrCharacterCannotPlaceInLocation)
+	}
+	cell := cells[rand.Intn(len(cells))]
+	o := game.CreateObjectFromArchetype(*key)
+	o.SetWID(wids.Next())
+	o.SetPosition(game.Position{X: cell.X, Y: cell.Y})
+	l.addObject(o)
+	return nil
+}
+
+// reachableCells returns the open cells that can be reached from the location's stairs without passing through locked doors. If there are no stairs, all open cells are returned.
+func (l *location) reachableCells() (cells []cellLocation) {
+	passable := func(p game.Position) bool {
+		if p.X < 0 || p.Y < 0 || p.X >= len(l.Cells) || p.Y >= len(l.Cells[p.X]) {
+			return false
+		}
+		// Closed doors can be opened, so long as they aren't locked.
+		if door := l.doorAt(p); door != nil {
+			return !door.IsLocked()
+		}
+		return l.Cells[p.X][p.Y].Blocks == game.MovementNone
+	}
+
+	var queue []game.Position
+	visited := make(map[game.Position]bool)
+	for _, o := range l.Objects {
+		if s, ok := o.(*game.Stairs); ok && passable(s.Position) && !visited[s.Position] {
+			queue = append(queue, s.Position)
+			visited[s.Position] = true
+		}
+	}
+	if len(queue) == 0 {
+		return l.filterCells(func(c game.Cell) bool {
+			return c.Blocks == game.MovementNone
+		})
+	}
+
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		cells = append(cells, cellLocation{
+			X:    p.X,
+			Y:    p.Y,
+			Cell: l.Cells[p.X][p.Y],
+		})
+		for x := -1; x <= 1; x++ {
+			for y := -1; y <= 1; y++ {
+				next := game.Position{X: p.X + x, Y: p.Y + y}
+				if !visited[next] && passable(next) {
+					visited[next] = true
+					queue = append(queue, next)
+				}
+			}
+		}
+	}
+	return
+}
+
+// spawnMob creates a non-player character from the given archetype and adds it to the location.
+func (l *location) spawnMob(a game.CharacterArchetype, p game.Position, wids *id.WIDGenerator) *game.Character {
+	c := game.CreateObjectFromArchetype(a).(*game.Character)
+	c.SetWID(wids.Next())
+	c.SetPosition(p)
+	c.Name = a.Title
+	c.Hurtable.CalculateFromCharacter(c)
+	c.Hurtable.CalculateArmorFromCharacter(c)
+	c.Health = c.MaxHealth
+	c.Damager.CalculateFromCharacter(c)
+	c.Movable.CalculateFromCharacter(c)
+	l.addObject(c)
+	l.brains[c.WID] = newBrain(a.Brain)
+	return c
+}
+
+// isPlayerCharacter returns true if the character is controlled by a player.
+func (l *location) isPlayerCharacter(c *game.Character) bool {
+	for _, pc := range l.playerCharacters {
+		if pc == c {
+			return true
+		}
+	}
+	return false
+}
+
+// npcCharacters returns all characters in the location that are not controlled by players.
+func (l *location) npcCharacters() (chars []*game.Character) {
+	for _, c := range l.Characters() {
+		if !l.isPlayerCharacter(c) {
+			chars = append(chars, c)
+		}
+	}
+	return
+}
+
+// nearestPlayerCharacter returns the player character closest to the given position and its distance.
+func (l *location) nearestPlayerCharacter(p game.Position) (nearest *game.Character, distance int) {
+	for _, c := range l.playerCharacters {
+		if d := p.Distance(c.Position); nearest == nil || d < distance {
+			nearest = c
+			distance = d
+		}
+	}
+	return
+}
+
+// noticedPlayerCharacter returns the nearest living player character that the given character can see and its distance. Players behind walls or other opaque cells go unnoticed.
+func (l *location) noticedPlayerCharacter(c *game.Character) (nearest *game.Character, distance int) {
+	visible := l.Cells.FOV(c.Position, game.SightRadius)
+	for _, pc := range l.playerCharacters {
+		if pc.IsDead() || !visible.Visible(pc.Position) {
+			continue
+		}
+		if d := c.Position.Distance(pc.Position); nearest == nil || d < distance {
+			nearest = pc
+			distance = d
+		}
+	}
+	return
+}
+
+// stairs returns all stairs in the location that lead in the given direction.
+func (l *location) stairs(dir game.StairsDirection) (stairs []*game.Stairs) {
+	for _, o := range l.Objects {
+		if s, ok := o.(*game.Stairs); ok {
+			if a, ok := s.Archetype.(game.StairsArchetype); ok && a.Direction == dir {
+				stairs = append(stairs, s)
+			}
+		}
+	}
+	return
+}
+
+// stairsAt returns the stairs at the given position, if any.
+func (l *location) stairsAt(p game.Position) *game.Stairs {
+	for _, o := range l.Objects {
+		if s, ok := o.(*game.Stairs); ok && s.GetPosition() == p {
+			return s
+		}
+	}
+	return nil
+}
+
+// doorAt returns the door at the given position, if any.
+func (l *location) doorAt(p game.Position) *game.Door {
+	for _, o := range l.Objects {
+		if d, ok := o.(*game.Door); ok && d.GetPosition() == p {
+			return d
+		}
+	}
+	return nil
+}
+
+// spawnLoot rolls the loot table at the location's depth and spawns the results at the given position. Mobs are spawned in the nearest open cells no one stands in.
+func (l *location) spawnLoot(table id.UUID, p game.Position) (objects game.Objects) {
+	for _, aid := range l.data.RollLoot(table, l.depth) {
+		if a, ok := l.data.Archetype(aid).(game.CharacterArchetype); ok {
+			if cp, ok := l.openCellNear(p); ok {
+				objects = append(objects, l.spawnMob(a, cp, l.wids))
+			}
+		} else if o, err := l.spawnObject(aid, p); err != nil {
+			log.Println(err)
+		} else {
+			objects = append(objects, o)
+		}
+	}
+	return objects
+}
+
+// fillBag fills the bag from its archetype's loot table. Loot that doesn't fit is left beside the bag.
+func (l *location) fillBag(bag *game.Bag) {
+	a, ok := bag.GetArchetype().(game.BagArchetype)
+	if !ok || a.Loot.IsNil() {
+		return
+	}
+	for _, o := range l.spawnLoot(a.Loot, bag.Position) {
+		if bag.CanHold(o) == nil {
+			bag.Pickup(o)
+		}
+	}
+}
+
+// slay removes a slain non-player character from the location, dropping its loot where it fell.
+func (l *location) slay(ch *game.Character) (events []game.Event) {
+	if a, ok := ch.GetArchetype().(game.CharacterArchetype); ok && !a.Drops.IsNil() {
+		for _, o := range l.spawnLoot(a.Drops, ch.Position) {
+			events = append(events, game.EventAdd{Object: o})
+		}
+	}
+	return append(events, l.DestroyObject(ch))
+}
+
+// openCellNear returns the given position if it is open and unoccupied, otherwise a random such cell beside it.
+func (l *location) openCellNear(p game.Position) (game.Position, bool) {
+	free := func(p game.Position) bool {
+		cell, err := l.Cells.At(p.X, p.Y)
+		return err == nil && cell.Blocks == game.MovementNone && l.characterAt(p) == nil
+	}
+	if free(p) {
+		return p, true
+	}
+	var open []game.Position
+	for x := p.X - 1; x <= p.X+1; x++ {
+		for y := p.Y - 1; y <= p.Y+1; y++ {
+			if cp := (game.Position{X: x, Y: y}); free(cp) {
+				open = append(open, cp)
+			}
+		}
+	}
+	if len(open) == 0 {
+		return p, false
+	}
+	return open[rand.Intn(len(open))], true
+}
+
+// canPickup returns true if the object can be picked up at all. Characters and fixtures, such as stairs, doors, traps, and chests, stay where they are, as do objects inside containers.
+func (l *location) canPickup(t game.Object) bool {
+	if t.GetContainerWID() > 0 {
+		return false
+	}
+	switch t := t.(type) {
+	case *game.Character, *game.Stairs, *game.Door, *game.Trap:
+		return false
+	case *game.Bag:
+		if a, ok := t.GetArchetype().(game.BagArchetype); ok && a.Fixed {
+			return false
+		}
+	}
+	return true
+}
+
+// characterAt returns the character at the given position, if any.
+func (l *location) characterAt(p game.Position) *game.Character {
+	for _, c := range l.Characters() {
+		if c.GetPosition() == p {
+			return c
+		}
+	}
 	return nil
 }
 
+// fixtureObject is an object to be placed from a fixture's key.
+type fixtureObject struct {
+	ID       id.UUID
+	Position game.Position
+	Min, Max game.Position // Bounds of the fixture the object was placed by.
+	Lock     *gen.Lock
+}
+
+// Contains returns true if the given cell is within the bounds of the object's fixture.
+func (fo fixtureObject) Contains(x, y int) bool {
+	return x >= fo.Min.X && x < fo.Max.X && y >= fo.Min.Y && y < fo.Max.Y
+}
+
 type cellLocation struct {
 	X, Y int
 	Cell game.Cell
@@ -325,33 +814,106 @@ func (l *location) process() (events []game.Event) {
 		events = append(events, l.processCharacter(c)...)
 	}
 
+	// Enter turns if the players have gotten themselves into a fight.
+	if !l.inTurns && l.inCombat() {
+		l.startTurns()
+		events = append(events, game.EventMode{
+			InTurns: true,
+		})
+	}
+
 	if !l.inTurns {
 		l.turnActionCount++
 	}
 
 	if (!l.inTurns && l.turnActionCount >= l.turnActionOOCLatch) || (l.inTurns && l.turnActionCount >= l.turnActionLatch) {
-		// TODO: Process non-player characters.
-		for _, o := range l.Objects {
-			switch o.(type) {
-			default:
-				// TODO
-			}
+		for _, c := range l.npcCharacters() {
+			events = append(events, l.processNPC(c)...)
 		}
 
 		l.turnActionCount = 0
 		l.turnCount++
 
+		events = append(events, l.tickStatuses()...)
+		events = append(events, l.respawn()...)
+
 		// Only send turn events if we're actually in what we consider to be turns.
 		if l.inTurns {
+			for _, c := range l.playerCharacters {
+				c.SpentActions = 0
+			}
 			events = append(events, game.EventTurn{
 				Turn: l.turnCount,
 			})
+			// Leave turns once things have quieted down.
+			if l.inCombat() {
+				l.quietTurns = 0
+			} else {
+				l.quietTurns++
+			}
+			if l.quietTurns >= combatQuietTurns {
+				l.stopTurns()
+				events = append(events, game.EventMode{
+					InTurns: false,
+				})
+			}
+		}
+	}
+	return events
+}
+
+// inCombat returns true if hostilities have occurred since the last check or if a hostile character is near a player. This clears the recorded hostilities.
+func (l *location) inCombat() bool {
+	if l.combatActivity {
+		l.combatActivity = false
+		return true
+	}
+	for _, c := range l.npcCharacters() {
+		if a, ok := c.Archetype.(game.CharacterArchetype); !ok || !a.Hostile {
+			continue
 		}
+		if target, distance := l.noticedPlayerCharacter(c); target != nil && distance <= combatSight {
+			return true
+		}
+	}
+	return false
+}
+
+// processNPC lets a non-player character's brain decide its desires for the turn and processes them as if they came from a player.
+func (l *location) processNPC(c *game.Character) (events []game.Event) {
+	brain, ok := l.brains[c.WID]
+	if !ok {
+		var name string
+		if a, ok := c.Archetype.(game.CharacterArchetype); ok {
+			name = a.Brain
+		}
+		brain = newBrain(name)
+		l.brains[c.WID] = brain
+	}
+
+	c.SpentActions = 0
+	for i := 0; i < c.Actions; i++ {
+		if c.Desire = brain.Think(l, c); c.Desire == nil {
+			break
+		}
+		events = append(events, l.processCharacter(c)...)
 	}
+	// Nobody is listening to an NPC's private events.
+	c.Events = nil
+
 	return events
 }
 
 func (l *location) processCharacter(c *game.Character) (events []game.Event) {
+	// The dead no longer act, but their actions are spent so as not to hold up the turn.
+	if c.IsDead() {
+		c.Desire = nil
+		if l.inTurns && c.SpentActions < c.Actions {
+			c.SpentActions = c.Actions
+			l.turnActionCount++
+		}
+		return nil
+	}
 	if c.Desire != nil {
 		if l.inTurns {
 			if c.SpentActions >= c.Actions {
@@ -366,7 +928,11 @@ func (l *location) processCharacter(c *game.Character) (events []game.Event) {
 		}
 		switch d := c.Desire.(type) {
 		case game.DesireMove:
-			if moveEvents, err := l.moveCharacter(c.WID, d.Direction); err == nil {
+			x, y := d.Direction.Position()
+			// Walking into a closed door tries to open it.
+			if door := l.doorAt(game.Position{X: c.X + x, Y: c.Y + y}); door != nil && door.Blocks() {
+				events = append(events, l.toggleOpen(c, door)...)
+			} else if moveEvents, err := l.moveCharacter(c.WID, d.Direction); err == nil {
 				events = append(events, moveEvents...)
 			} else {
 				// Make bump sounds if the character is moving in the same direction as their last desire.
@@ -415,7 +981,12 @@ func (l *location) processCharacter(c *game.Character) (events []game.Event) {
 						events = append(events, e)
 					}
 					if e, ok := e.(game.EventConsume); ok {
+						l.trainSkill(c, game.SkillCooking)
+						events = append(events, l.updateHungerState(c)...)
 						if e.Finished {
+							if a, ok := t.GetArchetype().(game.FoodArchetype); ok {
+								events = append(events, l.inflictStatuses(c, a.Effects)...)
+							}
 							events = append(events, game.EventSound{
 								FromPosition: c.GetPosition(),
 								Position:     c.GetPosition(),
@@ -423,6 +994,7 @@ func (l *location) processCharacter(c *game.Character) (events []game.Event) {
 							})
 							// Destroy the item.
 							events = append(events, l.DestroyObject(t))
+							events = append(events, l.updateEncumbrance(c)...)
 						} else {
 							// TODO: Make eating foods make different sounds, such as "slurp", "crunch", etc.
 							events = append(events, game.EventSound{
@@ -432,6 +1004,8 @@ func (l *location) processCharacter(c *game.Character) (events []game.Event) {
 							})
 						}
 					}
+				} else if item, isItem := t.(*game.Item); isItem {
+					events = append(events, l.useItem(c, item)...)
 				} else {
 					c.Events = append(c.Events, game.EventNotice{
 						Message: lc.T("You can't apply that."),
@@ -440,7 +1014,7 @@ func (l *location) processCharacter(c *game.Character) (events []game.Event) {
 			}
 		case game.DesirePickup:
 			if t := l.ObjectByWID(d.WID); t != nil {
-				if t.GetContainerWID() > 0 {
+				if !l.canPickup(t) {
 					c.Events = append(c.Events, game.EventNotice{
 						Message: lc.T("You can't pick that up."),
 					})
@@ -450,12 +1024,18 @@ func (l *location) processCharacter(c *game.Character) (events []game.Event) {
 							Message: lc.T("You can't reach that."),
 						})
 					} else {
-						events = append(events, c.Pickup(t))
-						events = append(events, game.EventSound{
-							FromPosition: c.Position,
-							Position:     c.Position,
-							Message:      lc.T("*snarf*"),
-						})
+						e := c.Pickup(t)
+						if _, ok := e.(game.EventNotice); ok {
+							c.Events = append(c.Events, e)
+						} else {
+							events = append(events, e)
+							events = append(events, game.EventSound{
+								FromPosition: c.Position,
+								Position:     c.Position,
+								Message:      lc.T("*snarf*"),
+							})
+							events = append(events, l.updateEncumbrance(c)...)
+						}
 					}
 				}
 			}
@@ -473,76 +1053,124 @@ func (l *location) processCharacter(c *game.Character) (events []game.Event) {
 							Position:     c.Position,
 							Message:      lc.T("*whump*"),
 						})
+						events = append(events, l.updateEncumbrance(c)...)
 					}
 				}
 			}
-		case game.DesireBash:
-			if t := l.ObjectByWID(d.WID); t != nil {
-				if hurtable, ok := t.(Hurtable); ok {
-					// TODO: Maybe only take unarmed damage?
-					damages := c.RollDamages()
-					hurtable.TakeDamages(damages)
-					events = append(events, game.EventDamages{
-						From:    c.WID,
-						Target:  d.WID,
-						Damages: damages,
-					})
+		case game.DesireStash:
+			t := l.ObjectByWID(d.WID)
+			b, ok := l.ObjectByWID(d.Bag).(*game.Bag)
+			if t == nil || !ok {
+				c.Events = append(c.Events, game.EventNotice{
+					Message: lc.T("You can't put that there."),
+				})
+			} else {
+				e := c.Stash(t, b)
+				if _, ok := e.(game.EventNotice); ok {
+					c.Events = append(c.Events, e)
+				} else {
+					events = append(events, e)
 					events = append(events, game.EventSound{
 						FromPosition: c.Position,
-						Position:     t.GetPosition(),
-						Message:      lc.T("*thud*"),
+						Position:     c.Position,
+						Message:      lc.T("*rustle*"),
 					})
+					events = append(events, l.updateEncumbrance(c)...)
 				}
-			} else {
-				c.Events = append(c.Events, game.EventNotice{
-					Message: lc.T("You kick at the air."),
-				})
 			}
-		case game.DesireOpen:
+		case game.DesireUnstash:
 			if t := l.ObjectByWID(d.WID); t != nil {
-				if openable, isOpenable := t.(Openable); isOpenable {
-					lockable, isLockable := t.(Lockable)
-					if openable.IsOpened() {
-						if err := openable.Close(); err == nil {
-							events = append(events, game.EventSound{
-								FromPosition: c.Position,
-								Position:     t.GetPosition(),
-								Message:      lc.T("*click*"),
-							})
-							c.Events = append(c.Events, game.EventNotice{
-								Message: lc.T("You close the door."),
-							})
-						} else if errors.Is(err, game.ErrAlreadyClosed) {
-							c.Events = append(c.Events, game.EventNotice{
-								Message: lc.T("It's already closed."),
-							})
-						}
-					} else if !openable.IsOpened() {
-						if isLockable && lockable.IsLocked() {
-							c.Events = append(c.Events, game.EventNotice{
-								Message: lc.T("It's locked."),
-							})
-						} else if err := openable.Open(); err == nil {
-							c.Events = append(c.Events, game.EventNotice{
-								Message: lc.T("You open the door."),
-							})
-							events = append(events, game.EventSound{
-								FromPosition: c.Position,
-								Position:     t.GetPosition(),
-								Message:      lc.T("*creak*"),
-							})
-						} else if errors.Is(err, game.ErrAlreadyOpen) {
-							c.Events = append(c.Events, game.EventNotice{
-								Message: lc.T("It's already open."),
-							})
-						}
+				if b, ok := l.ObjectByWID(t.GetContainerWID()).(*game.Bag); ok {
+					e := c.Unstash(t, b)
+					if _, ok := e.(game.EventNotice); ok {
+						c.Events = append(c.Events, e)
+					} else {
+						events = append(events, e)
+						events = append(events, game.EventSound{
+							FromPosition: c.Position,
+							Position:     c.Position,
+							Message:      lc.T("*rustle*"),
+						})
+						events = append(events, l.updateEncumbrance(c)...)
 					}
+				} else {
+					c.Events = append(c.Events, game.EventNotice{
+						Message: lc.T("That isn't in a bag."),
+					})
+				}
+			}
+		case game.DesireBash:
+			t := l.ObjectByWID(d.WID)
+			if t == nil {
+				t = l.bashTarget(c, d.Direction)
+			}
+			if t != nil {
+				if t == game.Object(c) || t.GetContainerWID() != 0 || t.GetPosition().Distance(c.Position) > 1 {
+					c.Events = append(c.Events, game.EventNotice{
+						Message: lc.T("You can't reach that."),
+					})
+				} else if b, ok := t.(Breakable); ok && !b.CanBreak() {
+					c.Events = append(c.Events, game.EventNotice{
+						Message: lc.T("It doesn't budge."),
+					})
+				} else if _, ok := t.(Hurtable); ok {
+					// TODO: Maybe only take unarmed damage?
+					events = append(events, l.damageObject(c, t, c.RollDamages(), lc.T("*thud*"))...)
+				} else {
+					c.Events = append(c.Events, game.EventNotice{
+						Message: lc.T("It doesn't budge."),
+					})
 				}
 			} else {
+				c.Events = append(c.Events, game.EventNotice{
+					Message: lc.T("You kick at the air."),
+				})
+			}
+		case game.DesireOpen:
+			if t := l.ObjectByWID(d.WID); t == nil {
 				c.Events = append(c.Events, game.EventNotice{
 					Message: lc.T("There is nothing there to open."),
 				})
+			} else if t.GetContainerWID() != 0 || t.GetPosition().Distance(c.Position) > 1 {
+				c.Events = append(c.Events, game.EventNotice{
+					Message: lc.T("You can't reach that."),
+				})
+			} else {
+				events = append(events, l.toggleOpen(c, t)...)
+			}
+		case game.DesireLock:
+			events = append(events, l.lock(c, d)...)
+		case game.DesireUnlock:
+			events = append(events, l.unlock(c, d)...)
+		case game.DesireSearch:
+			events = append(events, l.search(c)...)
+		case game.DesireDisarm:
+			events = append(events, l.disarm(c, d)...)
+		case game.DesireTravel:
+			var stairs *game.Stairs
+			if d.WID != 0 {
+				stairs, _ = l.ObjectByWID(d.WID).(*game.Stairs)
+			} else {
+				stairs = l.stairsAt(c.Position)
+			}
+			if stairs == nil {
+				c.Events = append(c.Events, game.EventNotice{
+					Message: lc.T("There is nowhere to go from here."),
+				})
+			} else if stairs.GetPosition() != c.GetPosition() {
+				c.Events = append(c.Events, game.EventNotice{
+					Message: lc.T("You can't reach that."),
+				})
+			} else {
+				l.travels = append(l.travels, travel{
+					character: c,
+					stairs:    stairs,
+				})
 			}
+		case game.DesireShoot:
+			events = append(events, l.shoot(c, d)...)
+		case game.DesireThrow:
+			events = append(events, l.throw(c, d)...)
 		case game.DesirePing:
 			events = append(events, game.EventPing{
 				From:     c.WID,
@@ -557,11 +1185,1034 @@ func (l *location) processCharacter(c *game.Character) (events []game.Event) {
 	return
 }
 
+// toggleOpen opens the object if it is closed and closes it if it is open. Locked objects stay closed.
+func (l *location) toggleOpen(c *game.Character, t game.Object) (events []game.Event) {
+	if openable, isOpenable := t.(Openable); isOpenable {
+		lockable, isLockable := t.(Lockable)
+		if openable.IsOpened() {
+			if err := openable.Close(); err == nil {
+				events = append(events, l.updateBlocks(t.GetPosition())...)
+				events = append(events, game.EventSound{
+					FromPosition: c.Position,
+					Position:     t.GetPosition(),
+					Message:      lc.T("*click*"),
+				})
+				c.Events = append(c.Events, game.EventNotice{
+					Message: lc.T("You close the door."),
+				})
+			} else if errors.Is(err, game.ErrAlreadyClosed) {
+				c.Events = append(c.Events, game.EventNotice{
+					Message: lc.T("It's already closed."),
+				})
+			}
+		} else if !openable.IsOpened() {
+			if isLockable && lockable.IsLocked() {
+				c.Events = append(c.Events, game.EventNotice{
+					Message: lc.T("It's locked."),
+				})
+			} else if err := openable.Open(); err == nil {
+				events = append(events, l.updateBlocks(t.GetPosition())...)
+				c.Events = append(c.Events, game.EventNotice{
+					Message: lc.T("You open the door."),
+				})
+				events = append(events, game.EventSound{
+					FromPosition: c.Position,
+					Position:     t.GetPosition(),
+					Message:      lc.T("*creak*"),
+				})
+				events = append(events, l.gainExperience(c, game.AttributeBrains, game.ExperienceOpen)...)
+			} else if errors.Is(err, game.ErrAlreadyOpen) {
+				c.Events = append(c.Events, game.EventNotice{
+					Message: lc.T("It's already open."),
+				})
+			}
+		}
+	}
+	return
+}
+
+// damageObject resolves the given damage rolls from a character against a hurtable object and returns the resulting events. Slain non-player characters are removed from the location.
+func (l *location) damageObject(c *game.Character, t game.Object, rolls []game.DamageResult, hitSound string) (events []game.Event) {
+	hurtable, ok := t.(Hurtable)
+	if !ok {
+		return nil
+	}
+	damages := hurtable.ResolveDamages(rolls)
+	hurtable.TakeDamages(damages)
+	events = append(events, game.EventDamages{
+		From:    c.WID,
+		Target:  t.GetWID(),
+		Damages: damages,
+	})
+	for _, damage := range damages {
+		if damage.Dodged {
+			continue
+		}
+		if w, ok := l.ObjectByWID(damage.Source).(*game.Weapon); ok {
+			if a, ok := w.Archetype.(game.WeaponArchetype); ok {
+				events = append(events, l.inflictStatuses(t, a.Effects)...)
+			}
+		}
+	}
+	// Only fighting characters grants experience, lest doors be bashed forever.
+	if target, ok := t.(*game.Character); ok {
+		l.combatActivity = true
+		for _, damage := range damages {
+			if damage.Dodged {
+				events = append(events, l.gainExperience(target, game.AttributeZooms, game.ExperienceDodge)...)
+				continue
+			}
+			events = append(events, l.gainWeaponExperience(c, damage.Source)...)
+			if damage.Critical {
+				events = append(events, l.gainExperience(c, game.AttributeFunk, game.ExperienceCritical)...)
+			}
+		}
+	}
+	sound := lc.T("*whiff*")
+	for _, damage := range damages {
+		if !damage.Dodged {
+			sound = hitSound
+			break
+		}
+	}
+	events = append(events, game.EventSound{
+		FromPosition: c.Position,
+		Position:     t.GetPosition(),
+		Message:      sound,
+	})
+	if target, ok := t.(*game.Character); ok && target.IsDead() && !l.isPlayerCharacter(target) {
+		events = append(events, l.slay(target)...)
+	} else if b, ok := t.(Breakable); ok && b.IsBroken() {
+		events = append(events, l.breakObject(c, t)...)
+	}
+	return events
+}
+
+// bashTarget returns what the character would bash in the given direction. Without a direction, the first breakable object beside or beneath the character is used.
+func (l *location) bashTarget(c *game.Character, dir game.MoveDirection) game.Object {
+	if dir != 0 && dir != game.CenterMoveDirection {
+		x, y := dir.Position()
+		p := game.Position{X: c.X + x, Y: c.Y + y}
+		for _, ch := range l.Characters() {
+			if ch.Position == p {
+				return ch
+			}
+		}
+		for _, o := range l.Objects {
+			if _, ok := o.(Hurtable); ok && o.GetContainerWID() == 0 && o.GetPosition() == p {
+				return o
+			}
+		}
+		return nil
+	}
+	for _, o := range l.Objects {
+		if b, ok := o.(Breakable); ok && b.CanBreak() && o.GetContainerWID() == 0 && o.GetPosition().Distance(c.Position) <= 1 {
+			return o
+		}
+	}
+	return nil
+}
+
+// breakObject breaks an object that has run out of health. Its contents spill out where it stood and it leaves behind its debris, if it has any.
+func (l *location) breakObject(c *game.Character, t game.Object) (events []game.Event) {
+	p := t.GetPosition()
+
+	if b, ok := t.(*game.Bag); ok {
+		// Copy the contents, as dropping modifies the inventory.
+		for _, o := range append(game.Objects{}, b.Inventory...) {
+			b.Drop(o)
+			o.SetPosition(p)
+			events = append(events, game.EventDrop{
+				Dropper:  b.WID,
+				Object:   o,
+				Position: p,
+			})
+		}
+	}
+
+	events = append(events, l.DestroyObject(t))
+	events = append(events, l.updateBlocks(p)...)
+
+	var debris id.UUID
+	switch a := t.GetArchetype().(type) {
+	case game.DoorArchetype:
+		debris = a.Debris
+	case game.BagArchetype:
+		debris = a.Debris
+	}
+	if !debris.IsNil() {
+		if o, err := l.spawnObject(debris, p); err != nil {
+			log.Println(err)
+		} else {
+			events = append(events, game.EventAdd{
+				Object: o,
+			})
+		}
+	}
+
+	events = append(events, game.EventSound{
+		FromPosition: c.Position,
+		Position:     p,
+		Message:      lc.T("*crash*"),
+	})
+	c.Events = append(c.Events, game.EventNotice{
+		Message: lc.T("It breaks apart!"),
+	})
+	return events
+}
+
+// spawnObject creates an object from the archetype with the given ID and adds it to the location at the given position.
+func (l *location) spawnObject(aid id.UUID, p game.Position) (game.Object, error) {
+	if l.data == nil || l.wids == nil {
+		return nil, ErrNoSuchArchetype
+	}
+	a := l.data.Archetype(aid)
+	if a == nil {
+		return nil, fmt.Errorf("could not spawn %s: %w", aid, ErrNoSuchArchetype)
+	}
+	o := game.CreateObjectFromArchetype(a)
+	if o == nil {
+		return nil, fmt.Errorf("could not spawn %s: %w", aid, ErrNoSuchArchetype)
+	}
+	o.SetWID(l.wids.Next())
+	o.SetPosition(p)
+	l.addObject(o)
+	return o, nil
+}
+
+// updateLighting recomputes the illumination of the location's cells. This is skipped if no lights have moved or changed and no cells have changed what they block.
+func (l *location) updateLighting() {
+	if l.tileAmbient == nil {
+		l.updateTileLighting()
+	}
+	sources := l.LightSources()
+	if l.lighting != nil && !l.lightingStale && slices.Equal(sources, l.lightSources) {
+		return
+	}
+	l.lightSources = sources
+	l.lightingStale = false
+	l.lighting = l.Cells.Lighting(func(x, y int) float64 {
+		return l.tileAmbient[x][y]
+	}, append(slices.Clip(sources), l.tileLights...))
+}
+
+// updateTileLighting caches the ambient light and lights of the location's tiles, as these only change along with the tiles themselves.
+func (l *location) updateTileLighting() {
+	tiles := make(map[id.UUID]game.TileArchetype)
+	if l.data != nil {
+		for _, t := range l.data.TileArchetypes() {
+			tiles[t.ID] = t
+		}
+	}
+	tile := func(tid id.UUID) (game.TileArchetype, bool) {
+		t, ok := tiles[tid]
+		return t, ok
+	}
+	l.tileLights = l.TileLightSources(tile)
+	l.tileAmbient = l.TileAmbient(tile)
+	l.lightingStale = true
+}
+
+// tileAt returns the tile archetype of the cell at the given position.
+func (l *location) tileAt(p game.Position) (game.TileArchetype, bool) {
+	if l.data == nil || p.X < 0 || p.Y < 0 || p.X >= len(l.Cells) || p.Y >= len(l.Cells[p.X]) {
+		return game.TileArchetype{}, false
+	}
+	tid := l.Cells[p.X][p.Y].TileID
+	if tid == nil {
+		return game.TileArchetype{}, false
+	}
+	t, err := l.data.Tile(*tid)
+	return t, err == nil
+}
+
+// enterTile applies the effects of the tile the character has just entered, such as extra movement cost and damage.
+func (l *location) enterTile(ch *game.Character) (events []game.Event) {
+	t, ok := l.tileAt(ch.Position)
+	if !ok {
+		return nil
+	}
+	l.spendActions(ch, t.MoveCost)
+	if t.Liquid {
+		events = append(events, game.EventSound{
+			FromPosition: ch.Position,
+			Position:     ch.Position,
+			Message:      lc.T("*splash*"),
+		})
+	}
+	if t.Damage > 0 {
+		damages := []game.DamageResult{{Damage: t.Damage}}
+		ch.TakeDamages(damages)
+		events = append(events, game.EventDamages{
+			Target:  ch.WID,
+			Damages: damages,
+		})
+	}
+	events = append(events, l.inflictStatuses(ch, t.Effects)...)
+	if ch.IsDead() && !l.isPlayerCharacter(ch) {
+		events = append(events, l.slay(ch)...)
+	}
+	return events
+}
+
+// spendActions spends additional actions from the character's turn, such as from moving through costly terrain. This only matters when in turns.
+func (l *location) spendActions(c *game.Character, actions int) {
+	if !l.inTurns {
+		return
+	}
+	for i := 0; i < actions && c.SpentActions < c.Actions; i++ {
+		c.SpentActions++
+		if c.SpentActions >= c.Actions {
+			l.turnActionCount++
+		}
+	}
+}
+
+// updateAllBlocks recomputes the blocking of every cell along with the lighting of their tiles. This should be done once a location is generated or restored.
+func (l *location) updateAllBlocks() {
+	for x, r := range l.Cells {
+		for y := range r {
+			l.updateBlocks(game.Position{X: x, Y: y})
+		}
+	}
+	l.updateTileLighting()
+}
+
+// updateBlocks recomputes the blocking and opacity of the cell at the given position from its tile and the objects within it, such as closed doors. Clients are told if it changed.
+func (l *location) updateBlocks(p game.Position) []game.Event {
+	if p.X < 0 || p.Y < 0 || p.X >= len(l.Cells) || p.Y >= len(l.Cells[p.X]) {
+		return nil
+	}
+	blocks := game.MovementNone
+	opaque := false
+	if t, ok := l.tileAt(p); ok {
+		blocks = t.Blocks
+		opaque = t.Opaque
+	}
+	if door := l.doorAt(p); door != nil && door.Blocks() {
+		blocks = game.MovementAll
+		opaque = true
+	}
+	if l.Cells[p.X][p.Y].Blocks == blocks && l.Cells[p.X][p.Y].Opaque == opaque {
+		return nil
+	}
+	l.Cells[p.X][p.Y].Blocks = blocks
+	l.Cells[p.X][p.Y].Opaque = opaque
+	l.lightingStale = true
+	return []game.Event{game.EventBlocks{
+		Position: p,
+		Blocks:   blocks,
+		Opaque:   opaque,
+	}}
+}
+
+// inflictStatuses rolls each of the given statuses and adds those that succeed to the target.
+func (l *location) inflictStatuses(t game.Object, statuses game.Statuses) (events []game.Event) {
+	hurtable, ok := t.(Hurtable)
+	if !ok {
+		return nil
+	}
+	for _, s := range statuses {
+		if !s.Roll() {
+			continue
+		}
+		if status, changed := hurtable.AddStatus(s); changed {
+			events = append(events, game.EventStatusAdd{
+				Target: t.GetWID(),
+				Status: status,
+			})
+		}
+	}
+	if c, ok := t.(*game.Character); ok && len(events) > 0 {
+		c.Movable.CalculateFromCharacter(c)
+	}
+	return events
+}
+
+// useItem uses a charge of the character's item and applies its effects, destroying the item once it is used up.
+func (l *location) useItem(c *game.Character, item *game.Item) (events []game.Event) {
+	e := c.Apply(item, true)
+	if _, ok := e.(game.EventNotice); ok {
+		c.Events = append(c.Events, e)
+		return nil
+	} else if e == nil {
+		return nil
+	}
+	events = append(events, e)
+	if a, ok := item.GetArchetype().(game.ItemArchetype); ok {
+		events = append(events, l.applyEffects(c, a.Effects)...)
+	}
+	if e, ok := e.(game.EventUse); ok && e.Finished {
+		events = append(events, l.DestroyObject(item))
+		events = append(events, l.updateEncumbrance(c)...)
+	}
+	return events
+}
+
+// applyEffects applies the effects to the character, such as from using an item.
+func (l *location) applyEffects(c *game.Character, effects game.Effects) (events []game.Event) {
+	for _, effect := range effects {
+		switch effect.Kind {
+		case game.EffectHeal:
+			if c.TakeHeal(effect.Amount) {
+				events = append(events, game.EventHealth{
+					Target: c.WID,
+					Health: c.Health,
+				})
+			}
+		case game.EffectCure:
+			events = append(events, l.cureStatuses(c, effect.Name)...)
+		case game.EffectTeleport:
+			events = append(events, l.teleport(c)...)
+		case game.EffectRevealMap:
+			c.MapRevealed = true
+			c.Events = append(c.Events, game.EventNotice{
+				Message: lc.T("The layout of the area comes to mind."),
+			})
+		case game.EffectStatus:
+			events = append(events, l.inflictStatuses(c, game.Statuses{effect.Status})...)
+		case game.EffectSpawn:
+			events = append(events, l.spawnAround(c.Position, effect.Object, max(effect.Count, 1))...)
+		case game.EffectIdentify:
+			events = append(events, l.identifyItems(c, c.Inventory)...)
+		}
+	}
+	return events
+}
+
+// cureStatuses removes the named status from the character, or every harmful status if name is empty.
+func (l *location) cureStatuses(c *game.Character, name string) (events []game.Event) {
+	for _, s := range append(game.Statuses{}, c.Statuses...) {
+		if (name == "" && game.HarmfulStatus(s.Name)) || s.Name == name {
+			c.RemoveStatus(s.Name)
+			events = append(events, game.EventStatusExpire{
+				Target: c.WID,
+				Name:   s.Name,
+			})
+		}
+	}
+	if len(events) > 0 {
+		c.Movable.CalculateFromCharacter(c)
+	}
+	return events
+}
+
+// teleport moves the character to a random open cell in the location.
+func (l *location) teleport(c *game.Character) (events []game.Event) {
+	cells := l.filterCells(func(cell game.Cell) bool {
+		return cell.Blocks == game.MovementNone
+	})
+	var open []game.Position
+	for _, cell := range cells {
+		p := game.Position{X: cell.X, Y: cell.Y}
+		if l.characterAt(p) == nil {
+			open = append(open, p)
+		}
+	}
+	if len(open) == 0 {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("You feel a brief tug."),
+		})
+		return nil
+	}
+	from := c.Position
+	c.Position = open[rand.Intn(len(open))]
+	events = append(events, game.EventSound{
+		FromPosition: from,
+		Position:     from,
+		Message:      lc.T("*poof*"),
+	})
+	events = append(events, game.EventPosition{
+		WID:      c.WID,
+		Position: c.Position,
+	})
+	events = append(events, l.enterTile(c)...)
+	events = append(events, l.springTraps(c)...)
+	return events
+}
+
+// spawnAround spawns count objects of the archetype at and around the given position. Characters are only spawned in open cells that no one stands in.
+func (l *location) spawnAround(p game.Position, aid id.UUID, count int) (events []game.Event) {
+	a, isCharacter := l.data.Archetype(aid).(game.CharacterArchetype)
+	for i := 0; i < count; i++ {
+		if !isCharacter {
+			if o, err := l.spawnObject(aid, p); err != nil {
+				log.Println(err)
+			} else {
+				events = append(events, game.EventAdd{Object: o})
+			}
+			continue
+		}
+		cp, ok := l.openCellNear(p)
+		if !ok {
+			break
+		}
+		mob := l.spawnMob(a, cp, l.wids)
+		events = append(events, game.EventAdd{Object: mob})
+	}
+	return events
+}
+
+// identifyItems identifies the unidentified items among the objects, including those inside bags.
+func (l *location) identifyItems(c *game.Character, objects game.Objects) (events []game.Event) {
+	for _, o := range objects {
+		switch o := o.(type) {
+		case *game.Item:
+			if !o.Identified {
+				o.Identified = true
+				events = append(events, game.EventIdentify{WID: o.WID})
+				c.Events = append(c.Events, game.EventNotice{
+					Message: lc.T("You identify %s."),
+					Args:    []any{o.Title()},
+				})
+			}
+		case *game.Bag:
+			events = append(events, l.identifyItems(c, o.Inventory)...)
+		}
+	}
+	return events
+}
+
+// tickStatuses ticks the statuses of every hurtable object in the location for a turn. Non-player characters slain by their statuses are removed from the location.
+func (l *location) tickStatuses() (events []game.Event) {
+	var slain []*game.Character
+	for _, o := range l.Objects {
+		hurtable, ok := o.(Hurtable)
+		if !ok {
+			continue
+		}
+		c, isCharacter := o.(*game.Character)
+		var health int
+		if isCharacter {
+			health = c.Health
+		}
+		ticked, expired := hurtable.TickStatuses()
+		for _, status := range ticked {
+			events = append(events, game.EventStatusTick{
+				Target: o.GetWID(),
+				Status: status,
+			})
+		}
+		for _, status := range expired {
+			events = append(events, game.EventStatusExpire{
+				Target: o.GetWID(),
+				Name:   status.Name,
+			})
+		}
+		if !isCharacter {
+			continue
+		}
+		if c.Health != health {
+			events = append(events, game.EventHealth{
+				Target: c.WID,
+				Health: c.Health,
+			})
+		}
+		if len(expired) > 0 {
+			c.Movable.CalculateFromCharacter(c)
+		}
+		if c.IsDead() && !l.isPlayerCharacter(c) {
+			slain = append(slain, c)
+		}
+	}
+	for _, c := range slain {
+		events = append(events, l.slay(c)...)
+	}
+	return events
+}
+
+// gainExperience grants experience to the character's attribute, returning a level-up event if one occurred.
+func (l *location) gainExperience(c *game.Character, a game.Attribute, amount game.AttributeLevel) []game.Event {
+	if e := c.GainExperience(a, amount); e != nil {
+		// Swole raises carry capacity, which may lighten the load.
+		return append([]game.Event{e}, l.updateEncumbrance(c)...)
+	}
+	return nil
+}
+
+// trainSkill trains the character's skill, letting them know if it reached a new level.
+func (l *location) trainSkill(c *game.Character, name string) {
+	if c.TrainSkill(name) {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("Your %s skill improves to %d."),
+			Args:    []any{name, c.Skills.Level(name)},
+		})
+	}
+}
+
+// gainWeaponExperience grants hit experience to the attributes of the weapon that was the source of a hit and trains the character's proficiency with it. Unarmed hits train Swole.
+func (l *location) gainWeaponExperience(c *game.Character, source id.WID) (events []game.Event) {
+	primary, secondary := game.AttributeSwole, game.AttributeNone
+	weaponType := game.WeaponTypeUnarmed
+	if source != c.WID {
+		// Anything else thrown is still a thrown weapon of sorts.
+		weaponType = game.WeaponTypeThrown
+	}
+	if w, ok := l.ObjectByWID(source).(*game.Weapon); ok {
+		if a, ok := w.Archetype.(game.WeaponArchetype); ok {
+			if a.PrimaryAttribute != game.AttributeNone {
+				primary = a.PrimaryAttribute
+			}
+			secondary = a.SecondaryAttribute
+			weaponType = a.WeaponType
+		}
+	}
+	l.trainSkill(c, game.WeaponSkill(weaponType))
+	events = append(events, l.gainExperience(c, primary, game.ExperienceHit)...)
+	events = append(events, l.gainExperience(c, secondary, game.ExperienceHit/2)...)
+	return events
+}
+
+// traceLine follows a line of fire from one position towards another for up to maxRange cells. It returns the first character or blocking object in the way, if any, and the last open position reached.
+func (l *location) traceLine(from, to game.Position, maxRange int, ignore id.WID) (hit game.Object, end game.Position) {
+	end = from
+	for i, p := range game.Line(from, to) {
+		if i >= maxRange {
+			break
+		}
+		cell, err := l.Cells.At(p.X, p.Y)
+		if err != nil || cell.Blocks == game.MovementAll {
+			return nil, end
+		}
+		for _, o := range l.Objects {
+			if o.GetWID() == ignore || o.GetPosition() != p {
+				continue
+			}
+			if _, ok := o.(*game.Character); ok {
+				return o, p
+			}
+			if b, ok := o.(Blockable); ok && b.IsBlocked() {
+				if op, ok := o.(Openable); ok && op.IsOpened() {
+					continue
+				}
+				return o, end
+			}
+		}
+		end = p
+	}
+	return nil, end
+}
+
+// shoot fires the character's ranged weapon at a position.
+func (l *location) shoot(c *game.Character, d game.DesireShoot) (events []game.Event) {
+	var weapon *game.Weapon
+	for _, o := range c.Inventory {
+		if w, ok := o.(*game.Weapon); ok && w.Applied && (d.WID == 0 || d.WID == w.WID) {
+			if a, ok := w.Archetype.(game.WeaponArchetype); ok && a.WeaponType == game.WeaponTypeRange {
+				weapon = w
+				break
+			}
+		}
+	}
+	if weapon == nil {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("You have nothing to shoot with."),
+		})
+		return nil
+	}
+	damage, ok := c.DamageFrom(weapon.WID)
+	if !ok {
+		return nil
+	}
+	maxRange := weapon.Archetype.(game.WeaponArchetype).Range
+	if maxRange <= 0 {
+		maxRange = defaultShootRange
+	}
+
+	hit, end := l.traceLine(c.Position, d.Position, maxRange, c.WID)
+	if hit != nil {
+		end = hit.GetPosition()
+	}
+	events = append(events, game.EventProjectile{
+		From:         c.WID,
+		WID:          weapon.WID,
+		Weapon:       game.WeaponTypeRange,
+		FromPosition: c.Position,
+		Position:     end,
+	})
+	if hit != nil {
+		if _, ok := hit.(Hurtable); ok {
+			events = append(events, l.damageObject(c, hit, []game.DamageResult{c.RollDamage(damage)}, lc.T("*thwack*"))...)
+			return events
+		}
+	}
+	events = append(events, game.EventSound{
+		FromPosition: c.Position,
+		Position:     end,
+		Message:      lc.T("*thwip*"),
+	})
+	return events
+}
+
+// throw throws an object from the character's inventory at a position. The object lands where it stops.
+func (l *location) throw(c *game.Character, d game.DesireThrow) (events []game.Event) {
+	t := c.Inventory.ObjectByWID(d.WID)
+	if t == nil {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("You don't have that item."),
+		})
+		return nil
+	}
+
+	// Thrown weapons hit as hard as they are able, everything else is just a nuisance.
+	damage := game.Damage{
+		Source:  t.GetWID(),
+		Max:     1,
+		Reduced: true,
+		Weapon:  game.WeaponTypeThrown,
+	}
+	maxRange := defaultThrowRange
+	if w, ok := t.(*game.Weapon); ok {
+		if a, ok := w.Archetype.(game.WeaponArchetype); ok && a.WeaponType == game.WeaponTypeThrown {
+			if dmg, ok := c.DamageFrom(w.WID); ok {
+				damage = dmg
+			} else {
+				damage.Min = a.MinDamage
+				damage.Max = a.MaxDamage
+			}
+			if a.Range > 0 {
+				maxRange = a.Range
+			}
+		}
+	}
+
+	e := c.Drop(t)
+	if _, ok := e.(game.EventNotice); ok {
+		c.Events = append(c.Events, e)
+		return nil
+	}
+	events = append(events, l.updateEncumbrance(c)...)
+
+	hit, end := l.traceLine(c.Position, d.Position, maxRange, c.WID)
+	if hit != nil {
+		if _, ok := hit.(*game.Character); ok {
+			end = hit.GetPosition()
+		}
+	}
+	t.SetPosition(end)
+
+	events = append(events, game.EventProjectile{
+		From:         c.WID,
+		WID:          t.GetWID(),
+		Weapon:       game.WeaponTypeThrown,
+		FromPosition: c.Position,
+		Position:     end,
+	})
+	events = append(events, game.EventDrop{
+		Dropper:  c.WID,
+		Object:   t,
+		Position: end,
+	})
+	if hit != nil {
+		if _, ok := hit.(Hurtable); ok {
+			events = append(events, l.damageObject(c, hit, []game.DamageResult{c.RollDamage(damage)}, lc.T("*thunk*"))...)
+			return events
+		}
+	}
+	events = append(events, game.EventSound{
+		FromPosition: c.Position,
+		Position:     end,
+		Message:      lc.T("*clatter*"),
+	})
+	return events
+}
+
+// lockableInReach returns the lockable object with the given WID if it is beside or beneath the character. If wid is 0, the first one beside or beneath the character that is in the wanted locked state is returned.
+func (l *location) lockableInReach(c *game.Character, wid id.WID, locked bool) game.Object {
+	inReach := func(o game.Object) bool {
+		return o.GetContainerWID() == 0 && o.GetPosition().Distance(c.Position) <= 1
+	}
+	if wid != 0 {
+		if t := l.ObjectByWID(wid); t != nil && inReach(t) {
+			if _, ok := t.(Lockable); ok {
+				return t
+			}
+		}
+		return nil
+	}
+	for _, o := range l.Objects {
+		if lockable, ok := o.(Lockable); ok && lockable.IsLocked() == locked && inReach(o) {
+			return o
+		}
+	}
+	return nil
+}
+
+// lock locks an object with a key that fits it. Open objects must be closed first.
+func (l *location) lock(c *game.Character, d game.DesireLock) (events []game.Event) {
+	t := l.lockableInReach(c, d.WID, false)
+	if t == nil {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("There is nothing there to lock."),
+		})
+		return nil
+	}
+	lockable := t.(Lockable)
+	if lockable.IsLocked() {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("It's already locked."),
+		})
+		return nil
+	}
+	if openable, ok := t.(Openable); ok && openable.IsOpened() {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("You need to close it first."),
+		})
+		return nil
+	}
+	if c.KeyFor(lockable.GetLockID()) == nil {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("You have no key that fits."),
+		})
+		return nil
+	}
+
+	lockable.Lock()
+	c.Events = append(c.Events, game.EventNotice{
+		Message: lc.T("You lock it."),
+	})
+	events = append(events, game.EventLock{
+		Locker: c.WID,
+		WID:    t.GetWID(),
+		Locked: true,
+	})
+	events = append(events, game.EventSound{
+		FromPosition: c.Position,
+		Position:     t.GetPosition(),
+		Message:      lc.T("*clunk*"),
+	})
+	return events
+}
+
+// unlock unlocks an object with a key that fits it. Without a key, the character tries their luck with a lockpick, which may break on failure.
+func (l *location) unlock(c *game.Character, d game.DesireUnlock) (events []game.Event) {
+	t := l.lockableInReach(c, d.WID, true)
+	if t == nil {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("There is nothing there to unlock."),
+		})
+		return nil
+	}
+	lockable := t.(Lockable)
+	if !lockable.IsLocked() {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("It isn't locked."),
+		})
+		return nil
+	}
+
+	if c.KeyFor(lockable.GetLockID()) != nil {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("You unlock it."),
+		})
+	} else if pick := c.Lockpick(); pick != nil {
+		l.trainSkill(c, game.SkillLockpicking)
+		if rand.Float64() >= game.PickChance(c, lockable.GetDifficulty()) {
+			if rand.Float64() < game.PickBreakChance(c) {
+				c.Events = append(c.Events, game.EventNotice{
+					Message: lc.T("Your lockpick breaks."),
+				})
+				events = append(events, game.EventSound{
+					FromPosition: c.Position,
+					Position:     t.GetPosition(),
+					Message:      lc.T("*snap*"),
+				})
+				events = append(events, l.DestroyObject(pick))
+				events = append(events, l.updateEncumbrance(c)...)
+			} else {
+				c.Events = append(c.Events, game.EventNotice{
+					Message: lc.T("You fail to pick the lock."),
+				})
+				events = append(events, game.EventSound{
+					FromPosition: c.Position,
+					Position:     t.GetPosition(),
+					Message:      lc.T("*scritch*"),
+				})
+			}
+			return events
+		}
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("You pick the lock."),
+		})
+		events = append(events, l.gainExperience(c, game.AttributeBrains, game.ExperienceOpen)...)
+	} else {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("You have no key or lockpick for that."),
+		})
+		return nil
+	}
+
+	lockable.Unlock()
+	events = append(events, game.EventLock{
+		Locker: c.WID,
+		WID:    t.GetWID(),
+		Locked: false,
+	})
+	events = append(events, game.EventSound{
+		FromPosition: c.Position,
+		Position:     t.GetPosition(),
+		Message:      lc.T("*click*"),
+	})
+	return events
+}
+
+// traps returns the traps in the location.
+func (l *location) traps() (traps []*game.Trap) {
+	for _, o := range l.Objects {
+		if t, ok := o.(*game.Trap); ok {
+			traps = append(traps, t)
+		}
+	}
+	return traps
+}
+
+// springTraps sets off any armed traps the character has triggered. Revealed traps may be by-passed with enough Brains.
+func (l *location) springTraps(ch *game.Character) (events []game.Event) {
+	for _, t := range l.traps() {
+		a, ok := t.GetArchetype().(game.TrapArchetype)
+		if !ok || !t.Armed() || !a.Trigger.Triggers(t.Position, ch.Position) {
+			continue
+		}
+		if t.Revealed && rand.Float64() < game.TrapAvoidChance(ch, a.Difficulty) {
+			ch.Events = append(ch.Events, game.EventNotice{
+				Message: lc.T("You carefully avoid the trap."),
+			})
+			continue
+		}
+		events = append(events, l.springTrap(ch, t, a)...)
+		if ch.IsDead() {
+			break
+		}
+	}
+	if ch.IsDead() && !l.isPlayerCharacter(ch) {
+		events = append(events, l.slay(ch)...)
+	}
+	return events
+}
+
+// springTrap sets off the trap on the character, revealing it and spending it if it does not rearm.
+func (l *location) springTrap(ch *game.Character, t *game.Trap, a game.TrapArchetype) (events []game.Event) {
+	t.Revealed = true
+	if !a.Rearms {
+		t.Disarmed = true
+	}
+	ch.Events = append(ch.Events, game.EventNotice{
+		Message: lc.T("You set off a trap!"),
+	})
+	// Clients that could not see the trap before learn of it once their vision updates.
+	events = append(events, game.EventTrap{
+		Trigger:  ch.WID,
+		WID:      t.WID,
+		Revealed: t.Revealed,
+		Disarmed: t.Disarmed,
+	})
+	events = append(events, game.EventSound{
+		FromPosition: t.Position,
+		Position:     t.Position,
+		Message:      lc.T("*snap*"),
+	})
+	if damage := a.RollDamage(); damage > 0 {
+		damages := []game.DamageResult{{Damage: damage}}
+		ch.TakeDamages(damages)
+		events = append(events, game.EventDamages{
+			Target:  ch.WID,
+			Damages: damages,
+		})
+	}
+	events = append(events, l.inflictStatuses(ch, a.Effects)...)
+	return events
+}
+
+// search searches the cells around the character for hidden traps, with the chance of finding each depending on the character's Brains.
+func (l *location) search(c *game.Character) (events []game.Event) {
+	found := false
+	for _, t := range l.traps() {
+		if t.Revealed || t.Position.Distance(c.Position) > 1 {
+			continue
+		}
+		a, ok := t.GetArchetype().(game.TrapArchetype)
+		if !ok || rand.Float64() >= game.TrapDetectChance(c, a.Difficulty) {
+			continue
+		}
+		t.Revealed = true
+		found = true
+		events = append(events, l.gainExperience(c, game.AttributeBrains, game.ExperienceTrap)...)
+	}
+	if found {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("You find a trap!"),
+		})
+	} else {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("You find nothing."),
+		})
+	}
+	return events
+}
+
+// disarm attempts to disarm a revealed trap beside or beneath the character. Failing badly enough sets the trap off.
+func (l *location) disarm(c *game.Character, d game.DesireDisarm) (events []game.Event) {
+	var t *game.Trap
+	for _, o := range l.traps() {
+		if !o.Revealed || !o.Armed() || o.Position.Distance(c.Position) > 1 {
+			continue
+		}
+		if d.WID == 0 || o.WID == d.WID {
+			t = o
+			break
+		}
+	}
+	if t == nil {
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("There is no trap there to disarm."),
+		})
+		return nil
+	}
+	a, ok := t.GetArchetype().(game.TrapArchetype)
+	if !ok {
+		return nil
+	}
+
+	chance := game.TrapDisarmChance(c, a.Difficulty)
+	if rand.Float64() >= chance {
+		// Clumsy attempts are more likely to set the trap off.
+		if rand.Float64() >= chance {
+			events = append(events, l.springTrap(c, t, a)...)
+			if c.IsDead() && !l.isPlayerCharacter(c) {
+				events = append(events, l.slay(c)...)
+			}
+			return events
+		}
+		c.Events = append(c.Events, game.EventNotice{
+			Message: lc.T("You fail to disarm the trap."),
+		})
+		return nil
+	}
+
+	t.Disarmed = true
+	c.Events = append(c.Events, game.EventNotice{
+		Message: lc.T("You disarm the trap."),
+	})
+	events = append(events, game.EventTrap{
+		WID:      t.WID,
+		Revealed: t.Revealed,
+		Disarmed: t.Disarmed,
+	})
+	events = append(events, game.EventSound{
+		FromPosition: c.Position,
+		Position:     t.Position,
+		Message:      lc.T("*click*"),
+	})
+	events = append(events, l.gainExperience(c, game.AttributeBrains, game.ExperienceTrap)...)
+	return events
+}
+
 // startTurns is called when the location should start processing the world in terms of turns. This should be done when the players begin combat.
 func (l *location) startTurns() {
 	l.inTurns = true
 	l.turnCount = 0
 	l.turnActionCount = 0
+	l.quietTurns = 0
 	for _, c := range l.playerCharacters {
 		c.SpentActions = 0
 	}
@@ -571,17 +2222,22 @@ func (l *location) startTurns() {
 func (l *location) stopTurns() {
 	l.turnCount = 0
 	l.turnActionCount = 0
+	l.quietTurns = 0
 	l.inTurns = false
 }
 
 // DestroyObject removes the given object from the location and any container it may be in. EventRemove is returned, which should be sent from the server to the client.
 func (l *location) DestroyObject(target game.Object) game.Event {
 	if container := target.GetContainerWID(); container > 0 {
-		if c := l.Character(container); c != nil {
+		switch c := l.ObjectByWID(container).(type) {
+		case *game.Character:
+			c.Drop(target)
+		case *game.Bag:
 			c.Drop(target)
 		}
 	}
 	l.removeObject(target)
+	delete(l.brains, target.GetWID())
 	return game.EventRemove{
 		WID: target.GetWID(),
 	}
@@ -619,11 +2275,36 @@ func (l *location) handleClientMessage(cl *client, msg net.Message) error {
 	return nil
 }
 
+// Hunger effects.
+const (
+	starvationDamage = 1    // Damage taken from starving per energy use.
+	faintChance      = 0.25 // Chance to faint and lose the turn's actions per energy use while fainting.
+)
+
+// Default ranges for shooting and throwing when an archetype does not provide one.
+const (
+	defaultShootRange = 8
+	defaultThrowRange = 4
+)
+
+// Combat detection values.
+const (
+	combatSight      = 8 // Distance at which hostile characters notice players and start combat.
+	combatQuietTurns = 3 // Turns without hostilities before combat ends.
+)
+
+// locationConfig is used to configure the generation of a location.
 type locationConfig struct {
-	ID    id.UUID
+	ID    id.UUID // The place to generate from. If nil, a place is chosen by Depth.
 	Depth int
 }
 
+// travel is a request for a character to travel through the given stairs.
+type travel struct {
+	character *game.Character
+	stairs    *game.Stairs
+}
+
 // Character location errors.
 var (
 	ErrCharacterNotInLocation         = errors.New("character is not in location")
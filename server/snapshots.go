@@ -0,0 +1,225 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/kettek/morogue/game"
+	"github.com/kettek/morogue/id"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Snapshots is an interface for loading and saving world snapshots.
+type Snapshots interface {
+	Snapshots() (snapshots []worldSnapshot, err error)
+	SaveSnapshot(snapshot worldSnapshot) error
+}
+
+type snapshots struct {
+	db *bolt.DB
+}
+
+// NewSnapshots creates a new worlds bucket within the given database.
+func NewSnapshots(db *bolt.DB) (Snapshots, error) {
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists([]byte("worlds"))
+		return err
+	})
+
+	s := &snapshots{
+		db: db,
+	}
+
+	return s, err
+}
+
+// Snapshots returns all stored world snapshots. Snapshots that fail to decode are logged and skipped.
+func (s *snapshots) Snapshots() (snapshots []worldSnapshot, err error) {
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("worlds"))
+		return b.ForEach(func(k, v []byte) error {
+			var snapshot worldSnapshot
+			if err := json.Unmarshal(v, &snapshot); err != nil {
+				log.Println(errors.Join(ErrBadSnapshot, fmt.Errorf("world %s", k), err))
+				return nil
+			}
+			snapshots = append(snapshots, snapshot)
+			return nil
+		})
+	})
+	return
+}
+
+// SaveSnapshot saves the given world snapshot, replacing any previous snapshot of the same world.
+func (s *snapshots) SaveSnapshot(snapshot worldSnapshot) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte("worlds"))
+
+		buf, err := json.Marshal(&snapshot)
+		if err != nil {
+			return err
+		}
+
+		return b.Put([]byte(snapshot.Info.ID.String()), buf)
+	})
+}
+
+// worldSnapshot is the stored state of a world.
+type worldSnapshot struct {
+	Info      game.WorldInfo
+	Password  string
+	WIDTop    id.WID
+	Start     id.UUID
+	Locations []locationSnapshot
+}
+
+// locationSnapshot is the stored state of a location. Player characters and their belongings are not stored, as they belong to accounts.
+type locationSnapshot struct {
+	ID              id.UUID
+	Depth           int
+	Ambient         float64
+	Cells           game.Cells
+	Objects         []objectSnapshot
+	TurnCount       int
+	TurnActionCount int
+	InTurns         bool
+	Spawners        []spawner
+}
+
+// objectSnapshot is the stored state of an object. The WID and container are kept alongside the object, as they are not a part of the object's JSON.
+type objectSnapshot struct {
+	WID       id.WID
+	Container id.WID
+	Type      game.ObjectType
+	Data      game.RawMessage
+}
+
+// snapshot returns the current state of the world.
+func (w *world) snapshot() worldSnapshot {
+	s := worldSnapshot{
+		Info:     w.info,
+		Password: w.password,
+		WIDTop:   w.wids.Top(),
+	}
+	if w.start != nil {
+		s.Start = w.start.ID
+	}
+	for _, l := range w.locations {
+		s.Locations = append(s.Locations, l.snapshot())
+	}
+	return s
+}
+
+// snapshot returns the current state of the location.
+func (l *location) snapshot() locationSnapshot {
+	s := locationSnapshot{
+		ID:              l.ID,
+		Depth:           l.depth,
+		Ambient:         l.Ambient,
+		Cells:           l.Cells,
+		TurnCount:       l.turnCount,
+		TurnActionCount: l.turnActionCount,
+		InTurns:         l.inTurns,
+	}
+	for _, sp := range l.spawners {
+		s.Spawners = append(s.Spawners, *sp)
+	}
+
+	for _, o := range l.Objects {
+		if c, ok := o.(*game.Character); ok && l.isPlayerCharacter(c) {
+			continue
+		}
+		// Objects held by characters or bags are stored as part of their container's inventory.
+		if o.GetContainerWID() != 0 {
+			continue
+		}
+		b, err := json.Marshal(o)
+		if err != nil {
+			continue
+		}
+		s.Objects = append(s.Objects, objectSnapshot{
+			WID:       o.GetWID(),
+			Container: o.GetContainerWID(),
+			Type:      o.Type(),
+			Data:      b,
+		})
+	}
+	return s
+}
+
+// newWorldFromSnapshot creates a world from the given snapshot.
+func newWorldFromSnapshot(d *Data, s worldSnapshot) (*world, error) {
+	w := newWorld(d)
+	w.info = s.Info
+	w.password = s.Password
+	w.wids.SetTop(s.WIDTop)
+
+	var errs []error
+	for _, ls := range s.Locations {
+		l := newLocation()
+		l.ID = ls.ID
+		l.depth = ls.Depth
+		l.Ambient = ls.Ambient
+		l.data = w.data
+		l.wids = &w.wids
+		l.Cells = ls.Cells
+		l.turnCount = ls.TurnCount
+		l.turnActionCount = ls.TurnActionCount
+		l.inTurns = ls.InTurns
+		for _, sp := range ls.Spawners {
+			sp := sp
+			l.spawners = append(l.spawners, &sp)
+		}
+		for _, os := range ls.Objects {
+			o, err := game.ObjectWrapper{Type: os.Type, Data: os.Data}.ObjectJSON()
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			o.SetWID(os.WID)
+			o.SetContainerWID(os.Container)
+			w.assignArchetypes(o)
+			w.renewContents(o)
+			l.addObject(o)
+			if c, ok := o.(*game.Character); ok {
+				for _, o2 := range c.Inventory {
+					l.addObject(o2)
+				}
+			}
+		}
+		// Tiles may have changed since the snapshot was taken.
+		l.updateAllBlocks()
+		w.locations = append(w.locations, l)
+		if l.ID == s.Start {
+			w.start = l
+		}
+	}
+
+	if len(errs) > 0 {
+		return w, errors.Join(errs...)
+	}
+	return w, nil
+}
+
+// renewContents gives the contents of a restored character or bag new WIDs, as contained objects do not keep their WIDs.
+func (w *world) renewContents(o game.Object) {
+	var contents game.Objects
+	switch o := o.(type) {
+	case *game.Character:
+		contents = o.Inventory
+	case *game.Bag:
+		contents = o.Inventory
+	}
+	for _, o2 := range contents {
+		o2.SetWID(w.wids.Next())
+		o2.SetContainerWID(o.GetWID())
+		w.renewContents(o2)
+	}
+}
+
+// Snapshot-related errors.
+var (
+	ErrBadSnapshot = errors.New("bad world snapshot")
+)
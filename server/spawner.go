@@ -0,0 +1,115 @@
+package server
+
+import (
+	"math/rand"
+
+	"github.com/kettek/morogue/game"
+	"github.com/kettek/morogue/gen"
+	"github.com/kettek/morogue/id"
+)
+
+// spawner spawns mobs throughout a location as per a place's spawn rule.
+type spawner struct {
+	Rule     gen.SpawnEntry
+	MaxAlive int      // Most mobs alive at once, resolved from the rule when the location is generated.
+	Alive    []id.WID // Mobs spawned by the rule that are still around.
+	Wait     int      // Turns until the next respawn.
+}
+
+// spawnFrom rolls the spawner's table and spawns up to limit of the resulting mobs at or around the given position.
+func (l *location) spawnFrom(s *spawner, p game.Position, limit int) (mobs []*game.Character) {
+	for _, aid := range l.data.RollLoot(s.Rule.Table, l.depth) {
+		if len(mobs) >= limit {
+			break
+		}
+		a, ok := l.data.Archetype(aid).(game.CharacterArchetype)
+		if !ok {
+			continue
+		}
+		cp, ok := l.openCellNear(p)
+		if !ok {
+			break
+		}
+		c := l.spawnMob(a, cp, l.wids)
+		s.Alive = append(s.Alive, c.WID)
+		mobs = append(mobs, c)
+	}
+	return mobs
+}
+
+// populate spawns the initial mobs of the place's spawn rules in random open cells.
+func (l *location) populate(rules []gen.SpawnEntry) {
+	open := l.filterCells(func(c game.Cell) bool {
+		return c.Blocks == game.MovementNone
+	})
+	for _, rule := range rules {
+		s := &spawner{
+			Rule: rule,
+			Wait: rule.Interval,
+		}
+		count := int(rule.Density * float64(len(open)) / 100)
+		if rule.MaxAlive > 0 && count > rule.MaxAlive {
+			count = rule.MaxAlive
+		}
+		for tries := 0; len(s.Alive) < count && tries < count*4 && len(open) > 0; tries++ {
+			cell := open[rand.Intn(len(open))]
+			p := game.Position{X: cell.X, Y: cell.Y}
+			if l.characterAt(p) == nil {
+				l.spawnFrom(s, p, count-len(s.Alive))
+			}
+		}
+		s.MaxAlive = rule.MaxAlive
+		if s.MaxAlive == 0 {
+			s.MaxAlive = count
+		}
+		l.spawners = append(l.spawners, s)
+	}
+}
+
+// respawn counts down each spawner and spawns more mobs out of sight of the players once one is due and the spawner is below its limit.
+func (l *location) respawn() (events []game.Event) {
+	for _, s := range l.spawners {
+		if s.Rule.Interval <= 0 {
+			continue
+		}
+		var alive []id.WID
+		for _, wid := range s.Alive {
+			if c, ok := l.ObjectByWID(wid).(*game.Character); ok && !c.IsDead() {
+				alive = append(alive, wid)
+			}
+		}
+		s.Alive = alive
+		if len(s.Alive) >= s.MaxAlive {
+			continue
+		}
+		if s.Wait--; s.Wait > 0 {
+			continue
+		}
+		s.Wait = s.Rule.Interval
+		if p, ok := l.hiddenOpenCell(); ok {
+			for _, c := range l.spawnFrom(s, p, s.MaxAlive-len(s.Alive)) {
+				events = append(events, game.EventAdd{Object: c})
+			}
+		}
+	}
+	return events
+}
+
+// hiddenOpenCell returns a random open cell that is unoccupied and out of every player's sight.
+func (l *location) hiddenOpenCell() (game.Position, bool) {
+	open := l.filterCells(func(c game.Cell) bool {
+		return c.Blocks == game.MovementNone
+	})
+	for tries := 0; tries < 20 && len(open) > 0; tries++ {
+		cell := open[rand.Intn(len(open))]
+		p := game.Position{X: cell.X, Y: cell.Y}
+		if l.characterAt(p) != nil {
+			continue
+		}
+		if nearest, distance := l.nearestPlayerCharacter(p); nearest != nil && distance <= game.SightRadius {
+			continue
+		}
+		return p, true
+	}
+	return game.Position{}, false
+}
@@ -17,6 +17,7 @@ import (
 // they join and are no longer handled by the universe.
 type universe struct {
 	accounts               Accounts
+	snapshots              Snapshots
 	loggedInAccounts       []string
 	clients                []*client
 	clientChan             chan client
@@ -29,9 +30,10 @@ type universe struct {
 }
 
 // NewUniverse returns a new full "game server", a channel for receiving new clients on, and a channel for receiving client messages on.
-func NewUniverse(accounts Accounts, data *Data) (universe, chan client, chan struct{}) {
+func NewUniverse(accounts Accounts, snapshots Snapshots, data *Data) (universe, chan client, chan struct{}) {
 	u := universe{
 		accounts:               accounts,
+		snapshots:              snapshots,
 		clientChan:             make(chan client, 10),
 		checkChan:              make(chan struct{}, 10),
 		clientRemoveChan:       make(chan *client, 10),
@@ -48,16 +50,70 @@ func (u *universe) spinWorld(w *world) {
 	go w.loop(u.clientAddFromWorldChan, u.clientRemoveChan)
 }
 
+// restoreWorlds spins up all worlds stored in the universe's snapshots.
+func (u *universe) restoreWorlds() {
+	snapshots, err := u.snapshots.Snapshots()
+	if err != nil {
+		log.Println(err)
+	}
+	for _, s := range snapshots {
+		w, err := newWorldFromSnapshot(u.data, s)
+		if err != nil {
+			log.Println(err)
+		}
+		w.snapshots = u.snapshots
+		u.spinWorld(w)
+	}
+}
+
+// stopWorlds stops all worlds, allowing them to save their snapshots, and saves the accounts of any clients they return.
+func (u *universe) stopWorlds() {
+	for _, w := range u.worlds {
+		// Worlds whose loop has already returned, such as from failing to generate, have nothing to stop.
+		select {
+		case w.quitChan <- struct{}{}:
+		case <-w.doneChan:
+			continue
+		}
+		for done := false; !done; {
+			select {
+			case <-w.quitChan:
+				done = true
+			case <-w.doneChan:
+				done = true
+			case cl := <-u.clientAddFromWorldChan:
+				if err := u.accounts.SaveAccount(cl.account); err != nil {
+					log.Println(err)
+				}
+			}
+		}
+	}
+	// Save any stragglers.
+	for {
+		select {
+		case cl := <-u.clientAddFromWorldChan:
+			if err := u.accounts.SaveAccount(cl.account); err != nil {
+				log.Println(err)
+			}
+		default:
+			return
+		}
+	}
+}
+
 // Run starts the universe and returns a channel through which the world's
 // processing can be stopped. The universe runs in a goroutine and handles
-// client processing until a world takes over the client.
+// client processing until a world takes over the client. Once stopped, the
+// universe sends on the channel when all worlds have been saved.
 func (u *universe) Run() chan struct{} {
 	closeCh := make(chan struct{})
+	u.restoreWorlds()
 	go func() {
 		for {
 			select {
 			case <-closeCh:
-				// TODO: Cleanup worlds.
+				u.stopWorlds()
+				closeCh <- struct{}{}
 				return
 			case client := <-u.clientChan:
 				u.clients = append(u.clients, &client)
@@ -111,6 +167,9 @@ func (u *universe) loginClient(cl *client) {
 
 	var archetypes []game.Archetype
 	for _, a := range u.data.CharacterArchetypes() {
+		if !a.PlayerOnly {
+			continue
+		}
 		archetypes = append(archetypes, a)
 	}
 
@@ -221,7 +280,7 @@ func (u *universe) updateClient(cl *client) error {
 						Result:     ErrNotLoggedIn.Error(),
 					})
 				} else {
-					if !u.data.hasArchetype(m.Archetype) {
+					if a, ok := u.data.Archetype(m.Archetype).(game.CharacterArchetype); !ok || !a.PlayerOnly {
 						cl.conn.Write(net.CreateCharacterMessage{
 							ResultCode: 400,
 							Result:     ErrNoSuchArchetype.Error(),
@@ -246,6 +305,14 @@ func (u *universe) updateClient(cl *client) error {
 						if arch, ok := u.data.Archetype(char.ArchetypeID).(game.CharacterArchetype); ok {
 							char.Archetype = arch
 							char.Slots = arch.Slots.ToMap()
+							char.Skills = make(game.Skills)
+							for name, level := range arch.StartingSkills {
+								if _, ok := game.SkillByName(name); !ok {
+									log.Println("unknown starting skill:", name)
+									continue
+								}
+								char.Skills[name] = level
+							}
 							for _, au := range arch.StartingObjects {
 								if a := u.data.Archetype(au); a != nil {
 									if o := game.CreateObjectFromArchetype(a); o != nil {
@@ -256,6 +323,9 @@ func (u *universe) updateClient(cl *client) error {
 											char.Apply(o, false)
 										} else if _, ok := o.(*game.Weapon); ok {
 											char.Apply(o, false)
+										} else if item, ok := o.(*game.Item); ok {
+											// Characters know what they start with.
+											item.Identified = true
 										}
 									}
 								}
@@ -387,6 +457,7 @@ func (u *universe) updateClient(cl *client) error {
 				} else {
 					// TODO: Throttle this as well.
 					w := newWorld(u.data)
+					w.snapshots = u.snapshots
 					if m.Password != "" {
 						w.info.Private = true
 						w.password = m.Password
@@ -0,0 +1,272 @@
+package server
+
+import (
+	"github.com/kettek/morogue/game"
+	"github.com/kettek/morogue/id"
+	"github.com/kettek/morogue/net"
+)
+
+// vision tracks what a client's character can see in their location and what the client has been told about it.
+type vision struct {
+	location id.UUID
+	visible  game.Visibility          // Cells currently in view.
+	previous game.Visibility          // Cells in view before the last look.
+	memory   *game.LocationMemory     // The character's memory of the location, which holds the cells the client has been sent.
+	known    map[id.WID]game.Position // Objects the client has been sent, along with where they were last seen.
+	objects  map[id.WID]game.Object   // Lookup of the location's objects, rebuilt each update.
+}
+
+// reset computes the character's view of the location from scratch. Cells are remembered from the character's previous visits, but objects are not.
+func (v *vision) reset(l *location, c *game.Character) {
+	v.location = l.ID
+	v.known = make(map[id.WID]game.Position)
+	v.visible = nil
+	v.look(l, c)
+	v.memory = c.Memory.Location(l.ID, len(l.Cells), len(l.Cells[0]))
+	v.memory.RememberVisibility(v.visible)
+}
+
+// look recomputes the cells in view and the object lookup. Cells too dark to see are not in view.
+func (v *vision) look(l *location, c *game.Character) {
+	v.previous = v.visible
+	v.visible = l.Cells.FOV(c.Position, game.SightRadius).Lit(c.Position, l.lighting)
+	v.objects = make(map[id.WID]game.Object, len(l.Objects))
+	for _, o := range l.Objects {
+		v.objects[o.GetWID()] = o
+	}
+}
+
+// root returns the outermost container of the object, or the object itself if it is not contained.
+func (v *vision) root(o game.Object) game.Object {
+	for i := 0; i < 8 && o.GetContainerWID() != 0; i++ {
+		c, ok := v.objects[o.GetContainerWID()]
+		if !ok {
+			break
+		}
+		o = c
+	}
+	return o
+}
+
+// canSee returns true if the character can see the object. Hidden traps can't be seen. The character can always see themselves and what they carry, while the contents of other containers can only be seen from beside them if every bag they are within is unlocked. What other characters carry is never seen.
+func (v *vision) canSee(c *game.Character, o game.Object) bool {
+	if t, ok := o.(*game.Trap); ok && !t.Revealed {
+		return false
+	}
+	r := v.root(o)
+	if r.GetWID() == c.WID {
+		return true
+	}
+	if !v.visible.Visible(r.GetPosition()) {
+		return false
+	}
+	if o.GetContainerWID() == 0 {
+		return true
+	}
+	if r.GetPosition().Distance(c.Position) > 1 {
+		return false
+	}
+	for i := 0; i < 8 && o.GetContainerWID() != 0; i++ {
+		bag, ok := v.objects[o.GetContainerWID()].(*game.Bag)
+		if !ok || bag.IsLocked() {
+			return false
+		}
+		o = bag
+	}
+	return true
+}
+
+// knows returns true if the client has been told about the object.
+func (v *vision) knows(wid id.WID) bool {
+	_, ok := v.known[wid]
+	return ok
+}
+
+// learn marks the object as known to the client.
+func (v *vision) learn(o game.Object) {
+	v.known[o.GetWID()] = v.root(o).GetPosition()
+}
+
+// sees returns true if the event should be sent to the client. Events about objects are sent if the client knows of the object, while events at a position are sent if the position is in view. Events not listed are never sent. The client's known objects are updated to match what the event tells it.
+func (v *vision) sees(c *game.Character, e game.Event) bool {
+	switch e := e.(type) {
+	case game.EventAdd:
+		if v.canSee(c, e.Object) {
+			v.learn(e.Object)
+			return true
+		}
+		return false
+	case game.EventDrop:
+		if v.visible.Visible(e.Position) || v.knows(e.Dropper) {
+			v.learn(e.Object)
+			return true
+		}
+		return false
+	case game.EventRemove:
+		if v.knows(e.WID) {
+			delete(v.known, e.WID)
+			return true
+		}
+		return false
+	case game.EventPosition:
+		if o, ok := v.objects[e.WID]; ok && v.knows(e.WID) && v.canSee(c, o) {
+			v.learn(o)
+			return true
+		}
+		return false
+	case game.EventSound:
+		return v.visible.Visible(e.Position) || v.visible.Visible(e.FromPosition)
+	case game.EventBlocks:
+		return v.visible.Visible(e.Position)
+	case game.EventProjectile:
+		return v.knows(e.From) || v.visible.Visible(e.Position) || v.visible.Visible(e.FromPosition)
+	case game.EventApply:
+		return v.knows(e.Applier)
+	case game.EventConsume:
+		return v.knows(e.Consumer)
+	case game.EventPickup:
+		return v.knows(e.Picker)
+	case game.EventStash:
+		return v.knows(e.Stasher)
+	case game.EventUnstash:
+		return v.knows(e.Unstasher)
+	case game.EventLock:
+		return v.knows(e.WID)
+	case game.EventTrap:
+		return v.knows(e.WID)
+	case game.EventDamages:
+		return v.knows(e.Target)
+	case game.EventHealth:
+		return v.knows(e.Target)
+	case game.EventStatusAdd:
+		return v.knows(e.Target)
+	case game.EventStatusTick:
+		return v.knows(e.Target)
+	case game.EventStatusExpire:
+		return v.knows(e.Target)
+	// A character's hunger, load, and level-ups are their own business.
+	case game.EventHunger:
+		return e.WID == c.WID
+	case game.EventHungerState:
+		return e.WID == c.WID
+	case game.EventEncumbrance:
+		return e.WID == c.WID
+	case game.EventLevelUp:
+		return e.WID == c.WID
+	case game.EventUse:
+		return v.knows(e.User)
+	case game.EventIdentify:
+		return v.knows(e.WID)
+	case game.EventTurn, game.EventMode, game.EventPing:
+		// These concern everyone in the location.
+		return true
+	}
+	return false
+}
+
+// cells returns the location's cells as the client should know them, with cells the character does not remember left empty.
+func (v *vision) cells(l *location) game.Cells {
+	cells := game.NewCells(len(l.Cells), len(l.Cells[0]))
+	for x := range l.Cells {
+		for y := range l.Cells[x] {
+			if v.memory.Remembers(game.Position{X: x, Y: y}) {
+				cells[x][y] = l.Cells[x][y]
+			}
+		}
+	}
+	return cells
+}
+
+// visibleObjects returns the objects the character can see, marking them as known. Contained objects come before their containers so the client can link them up.
+func (v *vision) visibleObjects(c *game.Character) (objects game.Objects) {
+	var containers game.Objects
+	for _, o := range v.objects {
+		if !v.canSee(c, o) {
+			continue
+		}
+		v.learn(o)
+		if o.GetContainerWID() != 0 {
+			objects = append(objects, o)
+		} else {
+			containers = append(containers, o)
+		}
+	}
+	return append(objects, containers...)
+}
+
+// newCells returns the cells that have come into view since the last look. Cells are resent each time they come back into view, so remembered cells are refreshed.
+func (v *vision) newCells(l *location) (cells net.CellsMessage) {
+	cells.ID = l.ID
+	for x := range v.visible {
+		for y := range v.visible[x] {
+			p := game.Position{X: x, Y: y}
+			if v.visible[x][y] && !v.previous.Visible(p) {
+				cells.Cells = append(cells.Cells, net.Cell{Position: p, Cell: l.Cells[x][y]})
+				v.memory.Remember(p)
+			}
+		}
+	}
+	return
+}
+
+// reveal returns the cells the character does not yet remember, remembering them all.
+func (v *vision) reveal(l *location) (cells net.CellsMessage) {
+	cells.ID = l.ID
+	for x := range l.Cells {
+		for y := range l.Cells[x] {
+			p := game.Position{X: x, Y: y}
+			if !v.memory.Remembers(p) {
+				cells.Cells = append(cells.Cells, net.Cell{Position: p, Cell: l.Cells[x][y]})
+				v.memory.Remember(p)
+			}
+		}
+	}
+	return
+}
+
+// updateObjects returns the events that bring the client's objects up to date with what the character can see. Objects that have come into view are added, while known objects that have moved out of view or are gone are removed. Objects left behind out of view are remembered until their last seen position is in view again.
+func (v *vision) updateObjects(c *game.Character) (events net.EventsMessage) {
+	for wid, p := range v.known {
+		o, ok := v.objects[wid]
+		if ok && v.canSee(c, o) {
+			v.learn(o)
+			continue
+		}
+		// Characters move about, so they are forgotten as soon as they are out of view.
+		if !v.visible.Visible(p) {
+			if !ok {
+				continue
+			} else if _, isCharacter := v.root(o).(*game.Character); !isCharacter {
+				continue
+			}
+		}
+		delete(v.known, wid)
+		if evt, err := game.WrapEvent(game.EventRemove{WID: wid}); err == nil {
+			events.Events = append(events.Events, evt)
+		}
+	}
+	var added game.Objects
+	for _, o := range v.objects {
+		if !v.knows(o.GetWID()) && v.canSee(c, o) {
+			added = append(added, o)
+		}
+	}
+	// Sort contained objects ahead of their containers.
+	var containers game.Objects
+	for _, o := range added {
+		v.learn(o)
+		if o.GetContainerWID() != 0 {
+			if evt, err := game.WrapEvent(game.EventAdd{Object: o}); err == nil {
+				events.Events = append(events.Events, evt)
+			}
+		} else {
+			containers = append(containers, o)
+		}
+	}
+	for _, o := range containers {
+		if evt, err := game.WrapEvent(game.EventAdd{Object: o}); err == nil {
+			events.Events = append(events.Events, evt)
+		}
+	}
+	return
+}
@@ -3,10 +3,13 @@ package server
 import (
 	"errors"
 	"fmt"
+	"log"
+	"math/rand"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/kettek/morogue/game"
+	"github.com/kettek/morogue/gen"
 	"github.com/kettek/morogue/id"
 	"github.com/kettek/morogue/net"
 )
@@ -21,10 +24,13 @@ type world struct {
 	data              *Data
 	wids              id.WIDGenerator
 	locations         []*location
+	start             *location
+	snapshots         Snapshots
 	clientChan        chan *client
 	clientRemoveChan  chan *client
 	addToUniverseChan chan *client
 	quitChan          chan struct{}
+	doneChan          chan struct{} // Closed once the world's loop has returned.
 }
 
 func newWorld(d *Data) *world {
@@ -38,6 +44,7 @@ func newWorld(d *Data) *world {
 		},
 		data:       d,
 		quitChan:   make(chan struct{}),
+		doneChan:   make(chan struct{}),
 		clientChan: make(chan *client, 2),
 	}
 
@@ -47,8 +54,76 @@ func newWorld(d *Data) *world {
 	return w
 }
 
-func (w *world) generateLocation( /*locationInfo*/ ) {
-	// TODO: Generate and add location to locations.
+// generateLocation generates a new location from the given config and adds it to the world's locations.
+func (w *world) generateLocation(cfg locationConfig) (*location, error) {
+	var place gen.Place
+	if !cfg.ID.IsNil() {
+		p, err := w.data.Places.ByID(cfg.ID)
+		if err != nil {
+			return nil, err
+		}
+		place = p
+	} else {
+		places := w.data.Places.ByDepth(cfg.Depth)
+		if len(places) == 0 {
+			return nil, ErrNoPlaceForDepth
+		}
+		place = places[rand.Intn(len(places))]
+	}
+
+	lid, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	l := newLocation()
+	l.ID = id.UUID(lid)
+	l.depth = cfg.Depth
+	l.data = w.data
+	l.wids = &w.wids
+	if err := l.generate(place, w.data, &w.wids); err != nil {
+		return nil, err
+	}
+	w.locations = append(w.locations, l)
+
+	return l, nil
+}
+
+// location returns the location with the given ID.
+func (w *world) location(lid id.UUID) *location {
+	for _, l := range w.locations {
+		if l.ID == lid {
+			return l
+		}
+	}
+	return nil
+}
+
+// assignArchetypes assigns the archetype pointer of an object and all of its children. This is done when objects are restored from a snapshot, as they already have their WIDs.
+func (w *world) assignArchetypes(o game.Object) {
+	o.SetArchetype(w.data.Archetype(o.GetArchetypeID()))
+	switch o := o.(type) {
+	case *game.Character:
+		for _, o2 := range o.Inventory {
+			w.assignArchetypes(o2)
+		}
+		o.Damager.CalculateFromCharacter(o)
+		o.UpdateEncumbrance()
+		o.Movable.CalculateFromCharacter(o)
+	case *game.Bag:
+		for _, o2 := range o.Inventory {
+			w.assignArchetypes(o2)
+		}
+	}
+}
+
+// save stores a snapshot of the world, if the world has somewhere to store it.
+func (w *world) save() {
+	if w.snapshots == nil {
+		return
+	}
+	if err := w.snapshots.SaveSnapshot(w.snapshot()); err != nil {
+		log.Println(err)
+	}
 }
 
 // assignWIDs assigns a WID to an object and all of its children. This is done when any object is added to the world. Piggy-backing off this function is assigning the object's archetype pointer.
@@ -62,36 +137,38 @@ func (w *world) assignWIDs(o game.Object) {
 		}
 		o.Damager.CalculateFromCharacter(o)
 		o.Hurtable.CalculateFromCharacter(o)
+		o.Hurtable.CalculateArmorFromCharacter(o)
+		o.UpdateHungerState()
+		o.UpdateEncumbrance()
 		o.Movable.CalculateFromCharacter(o)
-		/*case *game.Container:
-		for _, i := range o.GetItems() {
-			w.assignWIDs(i)
-		}*/
+	case *game.Bag:
+		for _, o2 := range o.Inventory {
+			w.assignWIDs(o2)
+		}
 	}
 }
 
 func (w *world) loop(addToUniverseChan chan *client, clientRemoveChan chan *client) {
 	w.clientRemoveChan = clientRemoveChan
 	w.addToUniverseChan = addToUniverseChan
+	defer close(w.doneChan)
 	ticker := time.NewTicker(50 * time.Millisecond)
+	autosave := time.NewTicker(worldAutosaveInterval)
+	defer autosave.Stop()
 
-	// TODO: Ensure a starting location is being created.
-	lid, err := uuid.NewV4()
-	if err != nil {
-		panic(err)
+	// Only generate a starting location if we weren't restored from a snapshot.
+	if w.start == nil {
+		start, err := w.generateLocation(locationConfig{Depth: 0})
+		if err != nil {
+			fmt.Println("OH NO", err)
+			w.live = false
+			return
+		}
+		w.start = start
+		w.save()
 	}
-	start := newLocation()
-	start.ID = id.UUID(lid)
+	start := w.start
 	start.active = true
-	pid, err := id.UID(id.Place, "wilderness-outside")
-	if err != nil {
-		fmt.Println("OH NO", err)
-	}
-	err = start.generate(pid, w.data, &w.wids)
-	if err != nil {
-		fmt.Println("OH NO", err)
-	}
-	w.locations = append(w.locations, start)
 
 	w.live = true
 	for w.live {
@@ -99,10 +176,14 @@ func (w *world) loop(addToUniverseChan chan *client, clientRemoveChan chan *clie
 		select {
 		case <-w.quitChan:
 			w.live = false
+			w.save()
 			for _, cl := range w.clients {
 				w.addToUniverseChan <- cl
 			}
+			w.quitChan <- struct{}{}
 			return
+		case <-autosave.C:
+			w.save()
 		case cl := <-w.clientChan:
 			var char *game.Character
 			for _, ch := range cl.account.Characters {
@@ -114,13 +195,13 @@ func (w *world) loop(addToUniverseChan chan *client, clientRemoveChan chan *clie
 			// Boot back to universe if char is nil... TODO: Add error message.
 			if char == nil {
 				w.addToUniverseChan <- cl
-				return
+				break
 			}
 
 			// Add client as character to world. Boot back to universe if placement failed. TODO: Add error message.
 			if err := start.addCharacter(char); err != nil {
 				w.addToUniverseChan <- cl
-				return
+				break
 			}
 
 			// Now add the client to the list.
@@ -136,35 +217,7 @@ func (w *world) loop(addToUniverseChan chan *client, clientRemoveChan chan *clie
 				o.SetContainerWID(char.WID)
 			}
 
-			// Send starting location to client.
-			cl.conn.Write(net.LocationMessage{
-				ID:      start.ID,
-				Cells:   start.Cells,
-				Objects: start.Objects,
-			})
-
-			// Send client their character owner message
-			cl.conn.Write(net.OwnerMessage{
-				WID:        char.WID,
-				Inventory:  char.Inventory,
-				Skills:     char.Skills,
-				Attributes: char.Attributes,
-			})
-
-			// Send create to clients in location.
-			if evt, err := game.WrapEvent(game.EventAdd{
-				Object: cl.currentCharacter,
-			}); err == nil {
-				cls := w.clientsInLocation(start)
-				for _, cl2 := range cls {
-					if cl == cl2 {
-						continue
-					}
-					cl2.conn.Write(net.EventMessage{
-						Event: evt,
-					})
-				}
-			}
+			w.sendLocation(cl, start)
 		default:
 		}
 		// Select for timer delay.
@@ -173,6 +226,113 @@ func (w *world) loop(addToUniverseChan chan *client, clientRemoveChan chan *clie
 	}
 }
 
+// sendLocation sends the client's current location, as far as their character can see it, and their character to the client. Other clients in the location are told of the character once they can see them.
+func (w *world) sendLocation(cl *client, l *location) {
+	char := cl.currentCharacter
+
+	// Only send what the character can see.
+	l.updateLighting()
+	cl.vision.reset(l, char)
+	cl.conn.Write(net.LocationMessage{
+		ID:      l.ID,
+		Cells:   cl.vision.cells(l),
+		Objects: cl.vision.visibleObjects(char),
+		Ambient: l.Ambient,
+	})
+
+	// Let the client know if the location is in turns.
+	if evt, err := game.WrapEvent(game.EventMode{
+		InTurns: l.inTurns,
+		Turn:    l.turnCount,
+	}); err == nil {
+		cl.conn.Write(net.EventMessage{
+			Event: evt,
+		})
+	}
+
+	// Send client their character owner message
+	cl.conn.Write(net.OwnerMessage{
+		WID:        char.WID,
+		Inventory:  char.Inventory,
+		Skills:     char.Skills,
+		Attributes: char.Attributes,
+	})
+}
+
+// travelCharacter moves a character through the given stairs to the linked location, generating the location if it does not yet exist.
+func (w *world) travelCharacter(from *location, t travel) error {
+	var cl *client
+	for _, cl2 := range w.clients {
+		if cl2.currentCharacter == t.character {
+			cl = cl2
+			break
+		}
+	}
+	if cl == nil {
+		return ErrCharacterNotInLocation
+	}
+
+	a, ok := t.stairs.Archetype.(game.StairsArchetype)
+	if !ok {
+		return ErrNoSuchArchetype
+	}
+
+	// Find or generate our target location.
+	var to *location
+	var arrival *game.Stairs
+	if lid, wid := t.stairs.Linked(); t.stairs.IsLinked() {
+		if to = w.location(lid); to != nil {
+			arrival, _ = to.ObjectByWID(wid).(*game.Stairs)
+		}
+	}
+	if to == nil {
+		l, err := w.generateLocation(locationConfig{
+			ID:    a.Place,
+			Depth: from.depth + int(a.Direction),
+		})
+		if err != nil {
+			return err
+		}
+		to = l
+		// Link up with a set of stairs that leads back, if there is one.
+		if back := to.stairs(a.Direction.Opposite()); len(back) > 0 {
+			arrival = back[rand.Intn(len(back))]
+			arrival.Link(from.ID, t.stairs.WID)
+		}
+		if arrival != nil {
+			t.stairs.Link(to.ID, arrival.WID)
+		} else {
+			t.stairs.Link(to.ID, 0)
+		}
+	}
+
+	// Remove the character from their current location.
+	// Other clients in the location forget them once they are no longer seen.
+	if err := from.removeCharacter(t.character.WID); err != nil {
+		return err
+	}
+
+	// And add them to the target.
+	var err error
+	if arrival != nil {
+		err = to.addCharacterAt(t.character, arrival.GetPosition())
+	} else {
+		err = to.addCharacter(t.character)
+	}
+	if err != nil {
+		// Put them back where they came from so they aren't lost to the void.
+		if err := from.addCharacterAt(t.character, t.stairs.GetPosition()); err == nil {
+			w.sendLocation(cl, from)
+		}
+		return err
+	}
+	cl.currentLocation = to
+
+	w.sendLocation(cl, to)
+
+	return nil
+}
+
 func (w *world) update() error {
 	// Process clients.
 	i := 0
@@ -184,22 +344,9 @@ func (w *world) update() error {
 			fmt.Println(err)
 			// This shouldn't ever be nil, but let's be safe.
 			if cl.currentCharacter != nil {
+				// Clients that could see the character are told of its removal when their vision updates.
 				for _, l := range w.locations {
 					if err := l.removeCharacter(cl.currentCharacter.WID); err == nil {
-						// Send remove to clients in location, excluding the removed client.
-						if evt, err := game.WrapEvent(game.EventRemove{
-							WID: cl.currentCharacter.WID,
-						}); err == nil {
-							cls := w.clientsInLocation(l)
-							for _, cl2 := range cls {
-								if cl == cl2 {
-									continue
-								}
-								cl2.conn.Write(net.EventMessage{
-									Event: evt,
-								})
-							}
-						}
 						break
 					}
 				}
@@ -294,6 +441,17 @@ func (w *world) processLocation(l *location) error {
 	// Process events for all the clients in this location.
 	events := l.process()
 
+	// Move any travelling characters to their new locations.
+	for _, t := range l.travels {
+		if err := w.travelCharacter(l, t); err != nil {
+			t.character.Events = append(t.character.Events, game.EventNotice{
+				Message: lc.T("The way is shut."),
+			})
+			fmt.Println(err)
+		}
+	}
+	l.travels = nil
+
 	// Convert & send private client events.
 	for _, cl := range locationClients {
 		if cl.currentCharacter.Events != nil {
@@ -308,23 +466,62 @@ func (w *world) processLocation(l *location) error {
 		cl.currentCharacter.Events = nil
 	}
 
+	// Send skills to clients whose characters' skills have changed.
+	for _, cl := range locationClients {
+		if cl.currentCharacter.SkillsChanged {
+			cl.conn.Write(net.SkillsMessage{
+				WID:    cl.currentCharacter.WID,
+				Skills: cl.currentCharacter.Skills,
+			})
+			cl.currentCharacter.SkillsChanged = false
+		}
+	}
+
 	// Convert events to be sent to clients.
-	var eventsMessage net.EventsMessage
-	for _, event := range events {
+	wrapped := make([]game.EventWrapper, len(events))
+	for i, event := range events {
 		if evt, err := game.WrapEvent(event); err == nil {
-			eventsMessage.Events = append(eventsMessage.Events, evt)
+			wrapped[i] = evt
 		}
 	}
-	// Send events to clients.
-	if len(eventsMessage.Events) > 0 {
-		for _, cl := range locationClients {
+	// Send each client what their character can see, skipping any that have travelled elsewhere.
+	l.updateLighting()
+	for _, cl := range locationClients {
+		if cl.currentLocation != l {
+			continue
+		}
+		char := cl.currentCharacter
+		cl.vision.look(l, char)
+		if char.MapRevealed {
+			if cells := cl.vision.reveal(l); len(cells.Cells) > 0 {
+				cl.conn.Write(cells)
+			}
+			char.MapRevealed = false
+		}
+		if cells := cl.vision.newCells(l); len(cells.Cells) > 0 {
+			cl.conn.Write(cells)
+		}
+		var eventsMessage net.EventsMessage
+		for i, event := range events {
+			if wrapped[i].Type != "" && cl.vision.sees(char, event) {
+				eventsMessage.Events = append(eventsMessage.Events, wrapped[i])
+			}
+		}
+		if len(eventsMessage.Events) > 0 {
 			cl.conn.Write(eventsMessage)
 		}
+		if objects := cl.vision.updateObjects(char); len(objects.Events) > 0 {
+			cl.conn.Write(objects)
+		}
 	}
 
 	return nil
 }
 
+// worldAutosaveInterval is how often a world stores a snapshot of itself.
+const worldAutosaveInterval = 5 * time.Minute
+
 var (
+	ErrNoPlaceForDepth         = errors.New(lc.T("no place for depth"))
 	errRemoveLocationFromWorld = errors.New("this is also not an error lol")
 )